@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"gopkg.in/yaml.v3"
+)
+
+const configUsage = `usage: main config <command>
+
+commands:
+  print       print the resolved configuration as YAML, with the secrets redacted`
+
+// runConfig runs the config print subcommand, the configuration is printed only when it is valid
+func runConfig(args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"os"
+
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain/usecases"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/auth"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/routers"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/metrics"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/tracing"
 	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
@@ -26,6 +32,13 @@ import (
 // @license.url     http://www.apache.org/licenses/LICENSE-2.0.html
 // @host      localhost:8080
 // @BasePath  /
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT bearer token, sent as "Bearer <token>"
 // @externalDocs.description  OpenAPI
 // @externalDocs.url  https://swagger.io/specification/         https://swagger.io/resources/open-api/
 func main() {
@@ -35,17 +48,36 @@ func main() {
 	if err != nil {
 		log.Warn("Coudn't load .env file")
 	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfig(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	app := fx.New(
 		shared.Module,
 		config.Module,
+		auth.Module,
 		controllers.Module,
 		usecases.Module,
 		httpapi.Module,
+		metrics.Module,
+		tracing.Module,
 		routers.Module,
 		database.Module,
-		fx.Invoke(func(*fasthttp.Server) {}),
+		fx.Invoke(func(*fasthttp.Server, *httpapi.MetricsServer) {}),
 		fx.NopLogger,
 	)
 
+	// fx.NopLogger silences the startup errors, such as an unreachable database or a pending migration, so they are logged here
+	if err := app.Err(); err != nil {
+		shared.NewLogger().Fatal(context.Background(), "Failed to start the application", err)
+	}
 	app.Run()
 }
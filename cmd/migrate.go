@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database"
+)
+
+const migrateUsage = `usage: main migrate <command>
+
+commands:
+  up          apply every pending migration
+  down [n]    revert the last n applied migrations, 1 by default
+  status      list the migrations and when they were applied`
+
+// runMigrate runs the migrate up|down|status subcommands against the configured database
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	steps := 1
+	switch args[0] {
+	case "up", "status":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+	case "down":
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("the number of migrations to revert must be a positive integer, got %q", args[1])
+			}
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	db, err := database.NewPostgresDatabase(ctx, database.ConnectionString(cfg), database.DefaultRetryPolicy, nil)
+	if err != nil {
+		return err
+	}
+	migrator, err := database.NewMigrator(db, cfg.Database)
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("the database schema is up to date")
+		}
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	}
+}
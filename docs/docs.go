@@ -24,8 +24,216 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/log-level": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the minimum level of the logged entries",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the log level",
+                "responses": {
+                    "200": {
+                        "description": "Log Level",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogLevel"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the minimum level of the logged entries until the server restarts, e.g. to debug a live issue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Change the log level",
+                "parameters": [
+                    {
+                        "description": "Log Level, one of debug, info, warn or error",
+                        "name": "level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LogLevel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log Level",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogLevel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the audit trail of the farm and crop production mutations of the caller organization, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AuditEvent"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "farm",
+                            "crop_production"
+                        ],
+                        "type": "string",
+                        "description": "Type of the mutated resource",
+                        "name": "resource_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the mutated resource",
+                        "name": "resource_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user or API key that made the mutation",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events recorded at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events recorded before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of Audit Events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.AuditEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
         "/farms": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Get all farms with optional filters (e.g., crop type, land area)",
                 "consumes": [
                     "application/json"
@@ -54,21 +262,132 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Crop Type Filter",
+                        "description": "Case-insensitive farm name substring",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive farm address substring",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Crop types, repeated or comma separated",
                         "name": "crop_type",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether farms must produce any or all of the crop types",
+                        "name": "crop_type_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an irrigated (or non irrigated) crop production",
+                        "name": "is_irrigated",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an insured (or non insured) crop production",
+                        "name": "is_insured",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit Measure",
+                        "name": "unit_measure",
+                        "in": "query"
+                    },
                     {
                         "type": "number",
-                        "description": "Minimum Land Area",
+                        "description": "Minimum Land Area, in hectares unless unit is given",
                         "name": "minimum_land_area",
                         "in": "query"
                     },
                     {
                         "type": "number",
-                        "description": "Maximum Land Area",
+                        "description": "Maximum Land Area, in hectares unless unit is given",
                         "name": "maximum_land_area",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the land area range and of the returned land areas",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned in the next_cursor field of the previous page, enables the cursor pagination mode",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page in the cursor pagination mode",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted farms",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list deleted farms",
+                        "name": "only_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-land_area,name",
+                        "description": "Comma separated sort fields (name, land_area, created_at, updated_at), prefix a field with - to sort in descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -87,6 +406,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/shared.CustomError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -96,6 +421,14 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Create a new farm with crop production details",
                 "consumes": [
                     "application/json"
@@ -131,6 +464,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/shared.CustomError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -140,48 +479,1150 @@ const docTemplate = `{
                 }
             }
         },
-        "/farms/{id}": {
-            "delete": {
-                "description": "Deletes a farm by its unique ID",
-                "consumes": [
-                    "application/json"
+        "/farms/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Streams every farm matching the same filters as the farms listing, without pagination.\nThe CSV format has one row per farm and crop production pair, the NDJSON format has one farm per line.\nThe CSV files can be imported back as new farms, the ids, timestamps and deleted_at columns are ignored by the import.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
                     "Farm"
                 ],
-                "summary": "Delete a farm by ID",
+                "summary": "Export farms",
                 "parameters": [
                     {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
                         "type": "string",
-                        "description": "Farm ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
+                        "default": "csv",
+                        "description": "Export file format",
+                        "name": "format",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive farm name substring",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive farm address substring",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Crop types, repeated or comma separated",
+                        "name": "crop_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether farms must produce any or all of the crop types",
+                        "name": "crop_type_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an irrigated (or non irrigated) crop production",
+                        "name": "is_irrigated",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an insured (or non insured) crop production",
+                        "name": "is_insured",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit Measure",
+                        "name": "unit_measure",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum Land Area, in hectares unless unit is given",
+                        "name": "minimum_land_area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum Land Area, in hectares unless unit is given",
+                        "name": "maximum_land_area",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the land area range and of the exported land areas",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted farms",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only export deleted farms",
+                        "name": "only_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farms Export",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates farms in bulk from a CSV file, with one row per farm and crop production pair, or a NDJSON file, with one farm per line.\nThe CSV rows are grouped by the optional farm_key column, or the id column of the exported files, or else consecutive rows with the same name, land area, unit measure and address belong to the same farm.\nThe file is sent as the request body or in the file field of a multipart form.\nIn the all_or_nothing mode the farms are created in a single transaction and nothing is imported when a row is invalid.\nIn the skip_invalid mode the invalid farms are skipped and each valid farm is created on its own, the farms that could not be created are reported as failed.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Import farms",
+                "parameters": [
+                    {
+                        "enum": [
+                            "all_or_nothing",
+                            "skip_invalid"
+                        ],
+                        "type": "string",
+                        "default": "all_or_nothing",
+                        "description": "Import mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Import file format, detected from the file name or content type when omitted",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Import file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import Report",
+                        "schema": {
+                            "$ref": "#/definitions/models.FarmImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid Rows",
+                        "schema": {
+                            "$ref": "#/definitions/models.FarmImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregates the farms matching the same filters as the farms listing: farm count, land areas, crop type counts and irrigated/insured ratios, overall and grouped by unit measure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Farm statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive farm name substring",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive farm address substring",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Crop types, repeated or comma separated",
+                        "name": "crop_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether farms must produce any or all of the crop types",
+                        "name": "crop_type_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an irrigated (or non irrigated) crop production",
+                        "name": "is_irrigated",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only farms with an insured (or non insured) crop production",
+                        "name": "is_insured",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit Measure",
+                        "name": "unit_measure",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum Land Area, in hectares unless unit is given",
+                        "name": "minimum_land_area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum Land Area, in hectares unless unit is given",
+                        "name": "maximum_land_area",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the land area range and of the overall land areas",
+                        "name": "unit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted farms",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only aggregate deleted farms",
+                        "name": "only_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm Statistics",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FarmStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a farm and its crop productions by the farm unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Get a farm by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "hectares",
+                            "acres",
+                            "square_meters",
+                            "square_kilometers",
+                            "alqueires_paulista",
+                            "alqueires_mineiro"
+                        ],
+                        "type": "string",
+                        "description": "Unit of the returned land area",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Farm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a farm and its crop productions. Crop productions without an ID are created, the ones that are not sent are removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Replace a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Farm Data",
+                        "name": "farm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateFarmDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm Updated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Farm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a farm by its unique ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Delete a farm by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates only the provided farm fields. When crop_productions is provided it replaces the farm crop productions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Partially update a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Farm Data",
+                        "name": "farm",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchFarmDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm Updated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Farm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/crop-productions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all crop productions of the given farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CropProduction"
+                ],
+                "summary": "List the crop productions of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of Crop Productions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CropProduction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new crop production for the given farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CropProduction"
+                ],
+                "summary": "Add a crop production to a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Crop Production Data",
+                        "name": "cropProduction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CropProductionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Crop Production Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CropProduction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/crop-productions/{cropId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a crop production from the given farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CropProduction"
+                ],
+                "summary": "Delete a crop production",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crop Production ID",
+                        "name": "cropId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates only the provided crop production fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CropProduction"
+                ],
+                "summary": "Partially update a crop production",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crop Production ID",
+                        "name": "cropId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Crop Production Data",
+                        "name": "cropProduction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchCropProductionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Crop Production Updated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.CropProduction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/purge": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently removes a farm, deleted or not, and all of its crop productions. This operation can't be undone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Permanently delete a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a deleted farm together with the crop productions that were deleted with it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Farm"
+                ],
+                "summary": "Restore a deleted farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm Restored",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Farm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/shared.CustomError"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthcheck/live": {
+            "get": {
+                "description": "Reports that the process is up, it doesn't check the dependencies of the server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "Healthy",
                         "schema": {
-                            "$ref": "#/definitions/shared.CustomError"
+                            "$ref": "#/definitions/models.HealthReport"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/healthcheck/ready": {
+            "get": {
+                "description": "Reports whether the server can handle requests: the database answers a ping and the server is not shutting down.\nThe statistics of the database connection pool are included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "Ready",
                         "schema": {
-                            "$ref": "#/definitions/shared.CustomError"
+                            "$ref": "#/definitions/models.HealthReport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Unavailable or shutting down",
                         "schema": {
-                            "$ref": "#/definitions/shared.CustomError"
+                            "$ref": "#/definitions/models.HealthReport"
                         }
                     }
                 }
@@ -189,6 +1630,72 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "domain.AuditAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete",
+                "restore",
+                "purge"
+            ],
+            "x-enum-varnames": [
+                "AuditActionCreate",
+                "AuditActionUpdate",
+                "AuditActionDelete",
+                "AuditActionRestore",
+                "AuditActionPurge"
+            ]
+        },
+        "domain.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.AuditAction"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "actor_type": {
+                    "$ref": "#/definitions/domain.PrincipalType"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "$ref": "#/definitions/domain.AuditResourceType"
+                }
+            }
+        },
+        "domain.AuditResourceType": {
+            "type": "string",
+            "enum": [
+                "farm",
+                "crop_production"
+            ],
+            "x-enum-varnames": [
+                "AuditResourceFarm",
+                "AuditResourceCropProduction"
+            ]
+        },
         "domain.CropProduction": {
             "type": "object",
             "properties": {
@@ -209,6 +1716,26 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CropTypeStats": {
+            "type": "object",
+            "properties": {
+                "crop_production_count": {
+                    "type": "integer"
+                },
+                "crop_type": {
+                    "type": "string"
+                },
+                "farm_count": {
+                    "type": "integer"
+                },
+                "insured_ratio": {
+                    "type": "number"
+                },
+                "irrigated_ratio": {
+                    "type": "number"
+                }
+            }
+        },
         "domain.Farm": {
             "type": "object",
             "properties": {
@@ -236,14 +1763,99 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "unit_measure": {
+                "organization_id": {
                     "type": "string"
                 },
+                "unit_measure": {
+                    "$ref": "#/definitions/domain.UnitMeasure"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "domain.FarmStats": {
+            "type": "object",
+            "properties": {
+                "average_land_area": {
+                    "type": "number"
+                },
+                "crop_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CropTypeStats"
+                    }
+                },
+                "farm_count": {
+                    "type": "integer"
+                },
+                "land_area_unit": {
+                    "$ref": "#/definitions/domain.UnitMeasure"
+                },
+                "total_land_area": {
+                    "type": "number"
+                },
+                "unit_measures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UnitMeasureStats"
+                    }
+                }
+            }
+        },
+        "domain.PrincipalType": {
+            "type": "string",
+            "enum": [
+                "api_key",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "PrincipalTypeAPIKey",
+                "PrincipalTypeUser"
+            ]
+        },
+        "domain.UnitMeasure": {
+            "type": "string",
+            "enum": [
+                "hectares",
+                "acres",
+                "square_meters",
+                "square_kilometers",
+                "alqueires_paulista",
+                "alqueires_mineiro"
+            ],
+            "x-enum-varnames": [
+                "UnitMeasureHectares",
+                "UnitMeasureAcres",
+                "UnitMeasureSquareMeters",
+                "UnitMeasureSquareKilometers",
+                "UnitMeasureAlqueiresPaulista",
+                "UnitMeasureAlqueiresMineiro"
+            ]
+        },
+        "domain.UnitMeasureStats": {
+            "type": "object",
+            "properties": {
+                "average_land_area": {
+                    "type": "number"
+                },
+                "crop_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CropTypeStats"
+                    }
+                },
+                "farm_count": {
+                    "type": "integer"
+                },
+                "total_land_area": {
+                    "type": "number"
+                },
+                "unit_measure": {
+                    "$ref": "#/definitions/domain.UnitMeasure"
+                }
+            }
+        },
         "dto.CreateFarmDTO": {
             "type": "object",
             "required": [
@@ -296,6 +1908,211 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PatchCropProductionDTO": {
+            "type": "object",
+            "properties": {
+                "crop_type": {
+                    "type": "string",
+                    "enum": [
+                        "RICE",
+                        "CORN",
+                        "COFFEE",
+                        "SOYBEANS"
+                    ]
+                },
+                "is_insured": {
+                    "type": "boolean"
+                },
+                "is_irrigated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.PatchFarmDTO": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "crop_productions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UpdateCropProductionDTO"
+                    }
+                },
+                "land_area": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "unit_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateCropProductionDTO": {
+            "type": "object",
+            "required": [
+                "crop_type"
+            ],
+            "properties": {
+                "crop_type": {
+                    "type": "string",
+                    "enum": [
+                        "RICE",
+                        "CORN",
+                        "COFFEE",
+                        "SOYBEANS"
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_insured": {
+                    "type": "boolean"
+                },
+                "is_irrigated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UpdateFarmDTO": {
+            "type": "object",
+            "required": [
+                "address",
+                "land_area",
+                "name",
+                "unit_measure"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "crop_productions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UpdateCropProductionDTO"
+                    }
+                },
+                "land_area": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "unit_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DatabaseHealth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "idle": {
+                    "type": "integer"
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "max_idle_closed": {
+                    "type": "integer"
+                },
+                "max_lifetime_closed": {
+                    "type": "integer"
+                },
+                "max_open_connections": {
+                    "type": "integer"
+                },
+                "open_connections": {
+                    "type": "integer"
+                },
+                "reachable": {
+                    "type": "boolean"
+                },
+                "wait_count": {
+                    "description": "WaitCount and WaitDuration are the number of connections waited for and the total time spent waiting",
+                    "type": "integer"
+                },
+                "wait_duration": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FarmImportReport": {
+            "type": "object",
+            "properties": {
+                "failed_farms": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "imported_farms": {
+                    "type": "integer"
+                },
+                "invalid_farms": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FarmImportRowReport"
+                    }
+                }
+            }
+        },
+        "models.FarmImportRowReport": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "type": "object",
+            "properties": {
+                "appName": {
+                    "type": "string"
+                },
+                "database": {
+                    "$ref": "#/definitions/models.DatabaseHealth"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
         "shared.CustomError": {
             "type": "object",
             "properties": {
@@ -305,9 +2122,18 @@ const docTemplate = `{
             }
         }
     },
-    "externalDocs": {
-        "description": "OpenAPI",
-        "url": "https://swagger.io/specification/         https://swagger.io/resources/open-api/"
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT bearer token, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
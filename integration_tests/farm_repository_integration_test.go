@@ -2,9 +2,9 @@ package integration_tests
 
 import (
 	"context"
+	"errors"
 	"log"
-	"path/filepath"
-	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -13,9 +13,9 @@ import (
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/repositories"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
 	"github.com/arthurgavazza/farm-api-challenge/testutils"
 	"github.com/google/uuid"
-	"github.com/joho/godotenv"
 
 	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"github.com/stretchr/testify/assert"
@@ -23,59 +23,50 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 type IntegrationTestsSuite struct {
 	suite.Suite
 	postgresContainer *postgres.PostgresContainer
 	repo              *repositories.FarmRepository
+	cropRepo          *repositories.CropProductionRepository
+	// ctx is the context of a principal of the organization owning the test farms
+	ctx context.Context
+}
+
+func organizationContext(organizationID uuid.UUID) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), &domain.Principal{
+		ID:             uuid.NewString(),
+		Type:           domain.PrincipalTypeUser,
+		OrganizationID: organizationID,
+	})
 }
 
 func (is *IntegrationTestsSuite) SetupSuite() {
 	uuid.EnableRandPool()
 
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		log.Fatalf("unable to get caller info")
-	}
-	projectRoot := filepath.Join(filepath.Dir(filename), "../")
-	log.Println(projectRoot)
-	envFilePath := filepath.Join(projectRoot, ".env.test")
-	err := godotenv.Load(envFilePath)
-	if err != nil {
-		log.Fatalf("unable to load env file")
-	}
 	ctx := context.Background()
-	configuration := config.NewConfig()
-	postgresContainer, err := postgres.Run(ctx,
-		"postgres:17-alpine",
-		postgres.WithDatabase(configuration.Database.Name),
-		postgres.WithUsername(configuration.Database.User),
-		postgres.WithPassword(configuration.Database.Password),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(5*time.Second)),
-	)
-
+	postgresContainer, db, err := startPostgres(ctx)
 	if err != nil {
-		log.Fatalf("failed to start container: %s", err)
+		log.Fatalf("failed to start the database: %s", err)
 	}
-	connectionString, err := postgresContainer.ConnectionString(ctx)
+	is.postgresContainer = postgresContainer
+	migrator, err := database.NewMigrator(db, config.DatabaseConfig{})
 	if err != nil {
-		log.Fatalf("Failed to get connection string %s", err)
+		log.Fatalf("Failed to load the migrations %s", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		log.Fatalf("Failed to migrate the database %s", err)
 	}
-
-	is.postgresContainer = postgresContainer
-	db := database.NewPostgresDatabase(connectionString)
 	repo := repositories.NewFarmRepository(db, logger.NewLogger())
 	is.repo = repo
+	is.cropRepo = repositories.NewCropProductionRepository(db, logger.NewLogger())
+	is.ctx = organizationContext(uuid.New())
 
 }
 
 func (is *IntegrationTestsSuite) TestCreateFarm() {
-	ctx := context.Background()
+	ctx := is.ctx
 	farm := testutils.GenerateFakeFarm(nil, nil)
 	defer is.repo.DeleteFarm(ctx, farm.ID.String())
 	createdFarm, err := is.repo.CreateFarm(ctx, farm)
@@ -87,7 +78,7 @@ func (is *IntegrationTestsSuite) TestCreateFarm() {
 }
 
 func (is *IntegrationTestsSuite) TestListFarms() {
-	ctx := context.Background()
+	ctx := is.ctx
 	var farms []*domain.Farm
 	var wg sync.WaitGroup
 	defer func() {
@@ -121,9 +112,9 @@ func (is *IntegrationTestsSuite) TestListFarms() {
 	}
 	wg.Wait()
 	searchParams := &domain.FarmSearchParameters{
-		Page:     1,
-		PerPage:  3,
-		CropType: &cofeeCropType,
+		Page:      1,
+		PerPage:   3,
+		CropTypes: []domain.CropType{domain.CropType(cofeeCropType)},
 	}
 	coffeeCrops, err := is.repo.ListFarms(ctx, searchParams)
 	assert.NoError(is.T(), err)
@@ -133,7 +124,7 @@ func (is *IntegrationTestsSuite) TestListFarms() {
 	assert.Equal(is.T(), len(farmsWithCoffeeProductions), len(coffeeCrops.Items))
 
 	// since there are 6 farms with rice production and the provided PerPage argument = 3, the returned list should have 3 items
-	searchParams.CropType = &riceCropType
+	searchParams.CropTypes = []domain.CropType{domain.CropType(riceCropType)}
 	riceCrops, err := is.repo.ListFarms(ctx, searchParams)
 	assert.NoError(is.T(), err)
 	assert.NotNil(is.T(), riceCrops)
@@ -141,7 +132,7 @@ func (is *IntegrationTestsSuite) TestListFarms() {
 	assert.Equal(is.T(), int64(len(farmsWithRiceProductions)), riceCrops.TotalCount)
 
 	// since there are 3 farms with land area between 1000 and 2000 the returned result should reflect this number
-	searchParams.CropType = nil
+	searchParams.CropTypes = nil
 	searchParams.MinimumLandArea = testutils.PointerTo(float64(1000))
 	searchParams.MaximumLandArea = testutils.PointerTo(float64(2000))
 
@@ -152,8 +143,295 @@ func (is *IntegrationTestsSuite) TestListFarms() {
 
 }
 
+// TestListFarmsWithoutCropProductions is a regression test, farms created without crop productions
+// used to be left out of the listings by the INNER JOIN with the crop productions
+func (is *IntegrationTestsSuite) TestListFarmsWithoutCropProductions() {
+	ctx := is.ctx
+	name := "Fallow Farm " + uuid.NewString()
+	fallowFarm := testutils.GenerateFakeFarm(nil, nil)
+	fallowFarm.Name = name
+	fallowFarm.CropProductions = nil
+	_, err := is.repo.CreateFarm(ctx, fallowFarm)
+	assert.NoError(is.T(), err)
+	defer is.repo.PurgeFarm(ctx, fallowFarm.ID.String())
+
+	searchParams := &domain.FarmSearchParameters{Name: &name, Page: 1, PerPage: 10}
+	listed, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), listed.TotalCount)
+	require.Len(is.T(), listed.Items, 1)
+	assert.Equal(is.T(), fallowFarm.ID, listed.Items[0].ID)
+	assert.Empty(is.T(), listed.Items[0].CropProductions)
+
+	byCursor, err := is.repo.ListFarmsByCursor(ctx, &domain.FarmSearchParameters{Name: &name, Limit: 10})
+	assert.NoError(is.T(), err)
+	require.Len(is.T(), byCursor.Items, 1)
+	assert.Empty(is.T(), byCursor.Items[0].CropProductions)
+
+	// the crop type filter still requires a matching crop production
+	searchParams.CropTypes = []domain.CropType{domain.CropTypeCoffee}
+	withCoffee, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(0), withCoffee.TotalCount)
+}
+
+func (is *IntegrationTestsSuite) TestListFarmsWithRicherFilters() {
+	ctx := is.ctx
+	// the unique name scopes the assertions to the farms created by this test
+	namePrefix := "Filtered Farm " + uuid.NewString()
+	mixedFarm := testutils.GenerateFakeFarm(nil, nil)
+	mixedFarm.Name = namePrefix + " Mixed"
+	mixedFarm.CropProductions = []domain.CropProduction{
+		{ID: uuid.New(), FarmID: mixedFarm.ID, CropType: domain.CropTypeCoffee.String(), IsIrrigated: true},
+		{ID: uuid.New(), FarmID: mixedFarm.ID, CropType: domain.CropTypeRice.String(), IsIrrigated: false},
+	}
+	coffeeFarm := testutils.GenerateFakeFarm(nil, nil)
+	coffeeFarm.Name = namePrefix + " Coffee"
+	coffeeFarm.CropProductions = []domain.CropProduction{
+		{ID: uuid.New(), FarmID: coffeeFarm.ID, CropType: domain.CropTypeCoffee.String(), IsIrrigated: false},
+	}
+	for _, farm := range []*domain.Farm{mixedFarm, coffeeFarm} {
+		_, err := is.repo.CreateFarm(ctx, farm)
+		assert.NoError(is.T(), err)
+		defer is.repo.PurgeFarm(ctx, farm.ID.String())
+	}
+
+	// the name search is case-insensitive
+	name := strings.ToUpper(namePrefix)
+	searchParams := &domain.FarmSearchParameters{
+		Name:      &name,
+		Page:      1,
+		PerPage:   10,
+		CropTypes: []domain.CropType{domain.CropTypeCoffee, domain.CropTypeRice},
+	}
+	anyCropType, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(2), anyCropType.TotalCount)
+
+	searchParams.CropTypeMatch = domain.CropTypeMatchAll
+	allCropTypes, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), allCropTypes.TotalCount)
+	assert.Equal(is.T(), mixedFarm.ID, allCropTypes.Items[0].ID)
+	// the crop production filters don't hide the other crop productions of the matched farms
+	assert.Equal(is.T(), 2, len(allCropTypes.Items[0].CropProductions))
+
+	searchParams.CropTypes = nil
+	searchParams.IsIrrigated = testutils.PointerTo(true)
+	irrigated, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), irrigated.TotalCount)
+	assert.Equal(is.T(), mixedFarm.ID, irrigated.Items[0].ID)
+
+	searchParams.IsIrrigated = nil
+	searchParams.CreatedAfter = testutils.PointerTo(time.Now().Add(time.Hour))
+	createdLater, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(0), createdLater.TotalCount)
+}
+
+func (is *IntegrationTestsSuite) TestListFarmsFiltersLandAreaInHectares() {
+	ctx := is.ctx
+	namePrefix := "Measured Farm " + uuid.NewString()
+	acresFarm := testutils.GenerateFakeFarm(nil, testutils.PointerTo(float64(100)))
+	acresFarm.Name = namePrefix + " Acres"
+	acresFarm.UnitMeasure = domain.UnitMeasureAcres
+	hectaresFarm := testutils.GenerateFakeFarm(nil, testutils.PointerTo(float64(50)))
+	hectaresFarm.Name = namePrefix + " Hectares"
+	hectaresFarm.UnitMeasure = domain.UnitMeasureHectares
+	for _, farm := range []*domain.Farm{acresFarm, hectaresFarm} {
+		_, err := is.repo.CreateFarm(ctx, farm)
+		assert.NoError(is.T(), err)
+		defer is.repo.PurgeFarm(ctx, farm.ID.String())
+	}
+
+	// 100 acres are about 40.47 hectares, so only the hectares farm has at least 45 hectares
+	searchParams := &domain.FarmSearchParameters{
+		Name:            &namePrefix,
+		MinimumLandArea: testutils.PointerTo(float64(45)),
+		Sort:            []domain.FarmSort{{Field: domain.FarmSortByLandArea}},
+		Page:            1,
+		PerPage:         10,
+	}
+	inHectares, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), inHectares.TotalCount)
+	assert.Equal(is.T(), hectaresFarm.ID, inHectares.Items[0].ID)
+
+	// 45 acres are about 18.2 hectares, so both farms match and are sorted by their hectare value
+	searchParams.LandAreaUnit = domain.UnitMeasureAcres
+	inAcres, err := is.repo.ListFarms(ctx, searchParams)
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(2), inAcres.TotalCount)
+	assert.Equal(is.T(), acresFarm.ID, inAcres.Items[0].ID)
+	assert.Equal(is.T(), hectaresFarm.ID, inAcres.Items[1].ID)
+}
+
+func (is *IntegrationTestsSuite) TestGetFarmStats() {
+	ctx := is.ctx
+	namePrefix := "Stats Farm " + uuid.NewString()
+	hectaresFarm := testutils.GenerateFakeFarm(nil, testutils.PointerTo(float64(100)))
+	hectaresFarm.Name = namePrefix + " Hectares"
+	hectaresFarm.UnitMeasure = domain.UnitMeasureHectares
+	hectaresFarm.CropProductions = []domain.CropProduction{
+		{ID: uuid.New(), FarmID: hectaresFarm.ID, CropType: domain.CropTypeCorn.String(), IsIrrigated: true, IsInsured: true},
+		{ID: uuid.New(), FarmID: hectaresFarm.ID, CropType: domain.CropTypeCoffee.String()},
+	}
+	acresFarm := testutils.GenerateFakeFarm(nil, testutils.PointerTo(float64(100)))
+	acresFarm.Name = namePrefix + " Acres"
+	acresFarm.UnitMeasure = domain.UnitMeasureAcres
+	acresFarm.CropProductions = []domain.CropProduction{
+		{ID: uuid.New(), FarmID: acresFarm.ID, CropType: domain.CropTypeCorn.String(), IsInsured: true},
+	}
+	for _, farm := range []*domain.Farm{hectaresFarm, acresFarm} {
+		_, err := is.repo.CreateFarm(ctx, farm)
+		assert.NoError(is.T(), err)
+		defer is.repo.PurgeFarm(ctx, farm.ID.String())
+	}
+
+	stats, err := is.repo.GetFarmStats(ctx, &domain.FarmSearchParameters{Name: &namePrefix})
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(2), stats.FarmCount)
+	assert.InDelta(is.T(), 100+domain.UnitMeasureAcres.ToHectares(100), stats.TotalLandArea, 1e-6)
+	require.Len(is.T(), stats.CropTypes, 2)
+	assert.Equal(is.T(), domain.CropTypeCoffee.String(), stats.CropTypes[0].CropType)
+	assert.Equal(is.T(), domain.CropTypeCorn.String(), stats.CropTypes[1].CropType)
+	assert.Equal(is.T(), int64(2), stats.CropTypes[1].FarmCount)
+	assert.Equal(is.T(), 0.5, stats.CropTypes[1].IrrigatedRatio)
+	assert.Equal(is.T(), 1.0, stats.CropTypes[1].InsuredRatio)
+	require.Len(is.T(), stats.UnitMeasures, 2)
+	assert.Equal(is.T(), domain.UnitMeasureAcres, stats.UnitMeasures[0].UnitMeasure)
+	assert.Equal(is.T(), 100.0, stats.UnitMeasures[0].TotalLandArea)
+
+	// the stats honor the same filters as the listing
+	irrigatedStats, err := is.repo.GetFarmStats(ctx, &domain.FarmSearchParameters{
+		Name:        &namePrefix,
+		IsIrrigated: testutils.PointerTo(true),
+	})
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), irrigatedStats.FarmCount)
+}
+
+func (is *IntegrationTestsSuite) TestListFarmsByCursor() {
+	ctx := is.ctx
+	landArea := float64(4321)
+	farms := testutils.GenerateFarms(5, nil, &landArea)
+	for _, farm := range farms {
+		_, err := is.repo.CreateFarm(ctx, farm)
+		require.NoError(is.T(), err)
+		defer is.repo.DeleteFarm(ctx, farm.ID.String())
+	}
+
+	searchParams := &domain.FarmSearchParameters{
+		MinimumLandArea: &landArea,
+		MaximumLandArea: &landArea,
+		Limit:           2,
+	}
+	seenFarms := make(map[uuid.UUID]bool)
+	pages := 0
+	for {
+		page, err := is.repo.ListFarmsByCursor(ctx, searchParams)
+		require.NoError(is.T(), err)
+		pages++
+		for _, item := range page.Items {
+			assert.False(is.T(), seenFarms[item.ID], "farm returned twice while paging")
+			seenFarms[item.ID] = true
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		cursor, err := domain.DecodeFarmCursor(*page.NextCursor)
+		require.NoError(is.T(), err)
+		searchParams.Cursor = cursor
+	}
+	assert.Equal(is.T(), 3, pages)
+	assert.Len(is.T(), seenFarms, len(farms))
+}
+
+func (is *IntegrationTestsSuite) TestGetFarmByID() {
+	ctx := is.ctx
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	defer is.repo.DeleteFarm(ctx, farm.ID.String())
+	_, err := is.repo.CreateFarm(ctx, farm)
+	require.NoError(is.T(), err)
+
+	retrievedFarm, err := is.repo.GetFarmByID(ctx, farm.ID.String())
+	assert.NoError(is.T(), err)
+	assert.Equal(is.T(), farm.ID, retrievedFarm.ID)
+	assert.Equal(is.T(), farm.Name, retrievedFarm.Name)
+	assert.Len(is.T(), retrievedFarm.CropProductions, len(farm.CropProductions))
+
+	_, err = is.repo.GetFarmByID(ctx, uuid.New().String())
+	assert.Error(is.T(), err)
+}
+
+func (is *IntegrationTestsSuite) TestUpdateFarm() {
+	ctx := is.ctx
+	farm := testutils.GenerateFakeFarm(testutils.PointerTo(domain.CropTypeCoffee.String()), nil)
+	defer is.repo.DeleteFarm(ctx, farm.ID.String())
+	createdFarm, err := is.repo.CreateFarm(ctx, farm)
+	require.NoError(is.T(), err)
+	createdAt := createdFarm.CreatedAt
+	removedCropID := createdFarm.CropProductions[0].ID
+
+	updatedFarm := &domain.Farm{
+		ID:          farm.ID,
+		Name:        "Updated Farm",
+		LandArea:    farm.LandArea * 2,
+		UnitMeasure: farm.UnitMeasure,
+		Address:     "Updated Address",
+		CropProductions: []domain.CropProduction{
+			{CropType: domain.CropTypeRice.String(), IsIrrigated: true},
+		},
+	}
+	_, err = is.repo.UpdateFarm(ctx, updatedFarm)
+	require.NoError(is.T(), err)
+
+	retrievedFarm, err := is.repo.GetFarmByID(ctx, farm.ID.String())
+	require.NoError(is.T(), err)
+	assert.Equal(is.T(), "Updated Farm", retrievedFarm.Name)
+	assert.Equal(is.T(), farm.LandArea*2, retrievedFarm.LandArea)
+	assert.WithinDuration(is.T(), createdAt, retrievedFarm.CreatedAt, time.Millisecond)
+	assert.True(is.T(), retrievedFarm.UpdatedAt.After(createdAt))
+	require.Len(is.T(), retrievedFarm.CropProductions, 1)
+	assert.Equal(is.T(), domain.CropTypeRice.String(), retrievedFarm.CropProductions[0].CropType)
+	assert.NotEqual(is.T(), removedCropID, retrievedFarm.CropProductions[0].ID)
+}
+
+func (is *IntegrationTestsSuite) TestCropProductionLifecycle() {
+	ctx := is.ctx
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	defer is.repo.DeleteFarm(ctx, farm.ID.String())
+	_, err := is.repo.CreateFarm(ctx, farm)
+	require.NoError(is.T(), err)
+
+	cropProduction, err := domain.NewCropProduction(uuid.New(), farm.ID, domain.CropTypeSoybean, false, true)
+	require.NoError(is.T(), err)
+	_, err = is.cropRepo.CreateCropProduction(ctx, cropProduction)
+	require.NoError(is.T(), err)
+
+	cropProductions, err := is.cropRepo.ListCropProductions(ctx, farm.ID.String())
+	require.NoError(is.T(), err)
+	assert.Len(is.T(), cropProductions, 2)
+
+	cropProduction.IsIrrigated = true
+	_, err = is.cropRepo.UpdateCropProduction(ctx, cropProduction)
+	require.NoError(is.T(), err)
+	storedCropProduction, err := is.cropRepo.GetCropProduction(ctx, farm.ID.String(), cropProduction.ID.String())
+	require.NoError(is.T(), err)
+	assert.True(is.T(), storedCropProduction.IsIrrigated)
+
+	err = is.cropRepo.DeleteCropProduction(ctx, farm.ID.String(), cropProduction.ID.String())
+	require.NoError(is.T(), err)
+	_, err = is.cropRepo.GetCropProduction(ctx, farm.ID.String(), cropProduction.ID.String())
+	assert.Error(is.T(), err)
+
+	_, err = is.cropRepo.ListCropProductions(ctx, uuid.New().String())
+	assert.Error(is.T(), err)
+}
+
 func (is *IntegrationTestsSuite) TestDeleteFarm() {
-	ctx := context.Background()
+	ctx := is.ctx
 	farm := testutils.GenerateFakeFarm(nil, nil)
 	defer is.repo.DeleteFarm(ctx, farm.ID.String())
 	createdFarm, err := is.repo.CreateFarm(ctx, farm)
@@ -166,6 +444,89 @@ func (is *IntegrationTestsSuite) TestDeleteFarm() {
 	assert.Error(is.T(), err)
 }
 
+func (is *IntegrationTestsSuite) TestRestoreAndPurgeFarm() {
+	ctx := is.ctx
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	defer is.repo.PurgeFarm(ctx, farm.ID.String())
+	_, err := is.repo.CreateFarm(ctx, farm)
+	require.NoError(is.T(), err)
+
+	err = is.repo.DeleteFarm(ctx, farm.ID.String())
+	require.NoError(is.T(), err)
+	_, err = is.repo.GetFarmByID(ctx, farm.ID.String())
+	assert.Error(is.T(), err)
+
+	deletedFarms, err := is.repo.ListFarms(ctx, &domain.FarmSearchParameters{Page: 1, PerPage: 100, OnlyDeleted: true})
+	require.NoError(is.T(), err)
+	var deletedFarm *domain.Farm
+	for _, item := range deletedFarms.Items {
+		if item.ID == farm.ID {
+			deletedFarm = item
+		}
+	}
+	require.NotNil(is.T(), deletedFarm)
+	assert.NotNil(is.T(), deletedFarm.DeletedAt)
+	assert.Len(is.T(), deletedFarm.CropProductions, len(farm.CropProductions))
+
+	restoredFarm, err := is.repo.RestoreFarm(ctx, farm.ID.String())
+	require.NoError(is.T(), err)
+	assert.Nil(is.T(), restoredFarm.DeletedAt)
+	assert.Len(is.T(), restoredFarm.CropProductions, len(farm.CropProductions))
+
+	_, err = is.repo.RestoreFarm(ctx, farm.ID.String())
+	assert.Error(is.T(), err)
+
+	err = is.repo.PurgeFarm(ctx, farm.ID.String())
+	require.NoError(is.T(), err)
+	_, err = is.repo.RestoreFarm(ctx, farm.ID.String())
+	assert.Error(is.T(), err)
+}
+
+func (is *IntegrationTestsSuite) TestTenantIsolation() {
+	ctx := is.ctx
+	otherCtx := organizationContext(uuid.New())
+	name := "Tenant Isolation " + uuid.NewString()
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	farm.Name = name
+	_, err := is.repo.CreateFarm(ctx, farm)
+	require.NoError(is.T(), err)
+	defer is.repo.PurgeFarm(ctx, farm.ID.String())
+
+	var notFoundError *shared.NotFoundError
+	_, err = is.repo.GetFarmByID(otherCtx, farm.ID.String())
+	assert.True(is.T(), errors.As(err, &notFoundError))
+	_, err = is.cropRepo.ListCropProductions(otherCtx, farm.ID.String())
+	assert.True(is.T(), errors.As(err, &notFoundError))
+
+	searchParams := &domain.FarmSearchParameters{Name: &name, Page: 1, PerPage: 10, IncludeDeleted: true}
+	otherFarms, err := is.repo.ListFarms(otherCtx, searchParams)
+	require.NoError(is.T(), err)
+	assert.Empty(is.T(), otherFarms.Items)
+	assert.Equal(is.T(), int64(0), otherFarms.TotalCount)
+	otherStats, err := is.repo.GetFarmStats(otherCtx, &domain.FarmSearchParameters{Name: &name})
+	require.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(0), otherStats.FarmCount)
+
+	// another organization can neither delete nor purge the farm
+	err = is.repo.DeleteFarm(otherCtx, farm.ID.String())
+	assert.True(is.T(), errors.As(err, &notFoundError))
+	err = is.repo.PurgeFarm(otherCtx, farm.ID.String())
+	assert.True(is.T(), errors.As(err, &notFoundError))
+
+	farms, err := is.repo.ListFarms(ctx, searchParams)
+	require.NoError(is.T(), err)
+	require.Len(is.T(), farms.Items, 1)
+	assert.Equal(is.T(), farm.ID, farms.Items[0].ID)
+	assert.Nil(is.T(), farms.Items[0].DeletedAt)
+	assert.Len(is.T(), farms.Items[0].CropProductions, len(farm.CropProductions))
+	stats, err := is.repo.GetFarmStats(ctx, &domain.FarmSearchParameters{Name: &name})
+	require.NoError(is.T(), err)
+	assert.Equal(is.T(), int64(1), stats.FarmCount)
+
+	_, err = is.repo.ListFarms(context.Background(), searchParams)
+	assert.ErrorIs(is.T(), err, domain.ErrMissingOrganization)
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(IntegrationTestsSuite))
 }
@@ -0,0 +1,141 @@
+package integration_tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"gorm.io/gorm"
+)
+
+// baselineFarm and baselineCropProduction are the entities of the schema created by AutoMigrate
+// before the versioned migrations
+type baselineFarm struct {
+	ID              uuid.UUID                `gorm:"primaryKey"`
+	Name            string                   `gorm:"size:255;not null"`
+	LandArea        float64                  `gorm:"not null"`
+	UnitMeasure     string                   `gorm:"size:50;not null"`
+	Address         string                   `gorm:"size:255;not null"`
+	CropProductions []baselineCropProduction `gorm:"foreignKey:FarmID;constraint:OnDelete:CASCADE;"`
+	CreatedAt       time.Time                `gorm:"not null"`
+	UpdatedAt       time.Time                `gorm:"not null"`
+	DeletedAt       gorm.DeletedAt           `gorm:"index"`
+}
+
+func (baselineFarm) TableName() string {
+	return "farms"
+}
+
+type baselineCropProduction struct {
+	ID          uuid.UUID      `gorm:"primaryKey"`
+	FarmID      uuid.UUID      `gorm:"not null"`
+	CropType    string         `gorm:"size:50;not null"`
+	IsIrrigated bool           `gorm:"not null"`
+	IsInsured   bool           `gorm:"not null"`
+	CreatedAt   time.Time      `gorm:"not null"`
+	UpdatedAt   time.Time      `gorm:"not null"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (baselineCropProduction) TableName() string {
+	return "crop_productions"
+}
+
+func startMigrationsDatabase(t *testing.T) *gorm.DB {
+	postgresContainer, db, err := startPostgres(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(postgresContainer); err != nil {
+			t.Logf("failed to terminate container: %s", err)
+		}
+	})
+	return db
+}
+
+func TestMigrationsFromAutoMigrateBaseline(t *testing.T) {
+	ctx := context.Background()
+	db := startMigrationsDatabase(t)
+	require.NoError(t, db.AutoMigrate(&baselineFarm{}, &baselineCropProduction{}))
+	farm := baselineFarm{
+		ID:              uuid.New(),
+		Name:            "Green Acres",
+		LandArea:        10,
+		UnitMeasure:     "acre",
+		Address:         "123 Farm Lane",
+		CropProductions: []baselineCropProduction{{ID: uuid.New(), CropType: "RICE"}},
+	}
+	require.NoError(t, db.Create(&farm).Error)
+
+	organizationID := uuid.New()
+	migrator, err := database.NewMigrator(db, config.DatabaseConfig{DefaultOrganizationID: organizationID.String()})
+	require.NoError(t, err)
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(statuses))
+
+	var migrated struct {
+		OrganizationID   uuid.UUID
+		UnitMeasure      string
+		LandAreaHectares float64
+	}
+	require.NoError(t, db.Table("farms").Select("organization_id, unit_measure, land_area_hectares").Where("id = ?", farm.ID).Scan(&migrated).Error)
+	assert.Equal(t, organizationID, migrated.OrganizationID)
+	assert.Equal(t, "acres", migrated.UnitMeasure)
+	assert.InDelta(t, 4.0468564224, migrated.LandAreaHectares, 1e-9)
+	var cropCount int64
+	require.NoError(t, db.Table("crop_productions").Where("farm_id = ?", farm.ID).Count(&cropCount).Error)
+	assert.Equal(t, int64(1), cropCount)
+}
+
+func TestMigrationsRequireADefaultOrganizationForOrphanFarms(t *testing.T) {
+	ctx := context.Background()
+	db := startMigrationsDatabase(t)
+	require.NoError(t, db.AutoMigrate(&baselineFarm{}, &baselineCropProduction{}))
+	require.NoError(t, db.Create(&baselineFarm{ID: uuid.New(), Name: "Green Acres", LandArea: 10, UnitMeasure: "hectares", Address: "123 Farm Lane"}).Error)
+
+	migrator, err := database.NewMigrator(db, config.DatabaseConfig{})
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to apply migration 3_require_farm_organization")
+	assert.Contains(t, err.Error(), "DB_DEFAULT_ORGANIZATION_ID")
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, pending)
+	assert.Equal(t, int64(3), pending[0].Version)
+}
+
+// TestMigrationsRoundTrip reverts and re-applies every migration on its own database,
+// so the schema of the other integration tests is never reverted
+func TestMigrationsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db := startMigrationsDatabase(t)
+	migrator, err := database.NewMigrator(db, config.DatabaseConfig{})
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	reverted, err := migrator.Down(ctx, len(statuses))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(statuses))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(statuses))
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,58 @@
+package integration_tests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database"
+	"github.com/joho/godotenv"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+)
+
+// startPostgres starts an empty PostgreSQL container configured from .env.test and connects to it,
+// the caller terminates the container
+func startPostgres(ctx context.Context) (*postgres.PostgresContainer, *gorm.DB, error) {
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to get caller info")
+	}
+	projectRoot := filepath.Join(filepath.Dir(filename), "../")
+	if err := godotenv.Load(filepath.Join(projectRoot, ".env.test")); err != nil {
+		return nil, nil, fmt.Errorf("unable to load env file: %w", err)
+	}
+	configuration, err := config.NewConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid test configuration: %w", err)
+	}
+	postgresContainer, err := postgres.Run(ctx,
+		"postgres:17-alpine",
+		postgres.WithDatabase(configuration.Database.Name),
+		postgres.WithUsername(configuration.Database.User),
+		postgres.WithPassword(configuration.Database.Password),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start container: %w", err)
+	}
+	connectionString, err := postgresContainer.ConnectionString(ctx)
+	if err != nil {
+		_ = testcontainers.TerminateContainer(postgresContainer)
+		return nil, nil, fmt.Errorf("failed to get connection string: %w", err)
+	}
+	db, err := database.NewPostgresDatabase(ctx, connectionString, database.DefaultRetryPolicy, nil)
+	if err != nil {
+		_ = testcontainers.TerminateContainer(postgresContainer)
+		return nil, nil, fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	return postgresContainer, db, nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a key issued to a client application, only the key hash is stored
+type APIKey struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Name           string     `json:"name"`
+	KeyHash        string     `json:"-"`
+	Roles          []Role     `json:"roles"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash under which an API key is stored
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+type APIKeyRepository interface {
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+type AuditResourceType string
+
+const (
+	AuditResourceFarm           AuditResourceType = "farm"
+	AuditResourceCropProduction AuditResourceType = "crop_production"
+)
+
+func (t AuditResourceType) IsValid() bool {
+	switch t {
+	case AuditResourceFarm, AuditResourceCropProduction:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEvent records who mutated a resource and when, with JSON snapshots of the resource before and after the mutation.
+// Before is null for creations and After is null for purges
+type AuditEvent struct {
+	ID             uuid.UUID         `json:"id"`
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	ActorID        string            `json:"actor_id"`
+	ActorType      PrincipalType     `json:"actor_type"`
+	Action         AuditAction       `json:"action"`
+	ResourceType   AuditResourceType `json:"resource_type"`
+	ResourceID     string            `json:"resource_id"`
+	RequestID      string            `json:"request_id"`
+	Before         json.RawMessage   `json:"before" swaggertype:"object"`
+	After          json.RawMessage   `json:"after" swaggertype:"object"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// NewAuditEvent builds the audit event of a mutation made by the principal of the context, nil snapshots are left empty
+func NewAuditEvent(ctx context.Context, action AuditAction, resourceType AuditResourceType, resourceID string, before, after interface{}) (*AuditEvent, error) {
+	event := &AuditEvent{
+		ID:           uuid.New(),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		CreatedAt:    time.Now(),
+	}
+	if principal := PrincipalFromContext(ctx); principal != nil {
+		event.OrganizationID = principal.OrganizationID
+		event.ActorID = principal.ID
+		event.ActorType = principal.Type
+	}
+	event.RequestID = RequestIDFromContext(ctx)
+	var err error
+	if before != nil {
+		if event.Before, err = json.Marshal(before); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if event.After, err = json.Marshal(after); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+// AuditEventSearchParameters holds the audit events listing filters and pagination, the time range bounds are inclusive
+type AuditEventSearchParameters struct {
+	ResourceType *AuditResourceType `json:"resource_type"`
+	ResourceID   *string            `json:"resource_id"`
+	ActorID      *string            `json:"actor_id"`
+	From         *time.Time         `json:"from"`
+	To           *time.Time         `json:"to"` // exclusive, events recorded at this time are not matched
+	Page         int                `json:"page"`
+	PerPage      int                `json:"per_page"`
+}
+
+type AuditEventRepository interface {
+	// ListAuditEvents lists the audit events of the context principal organization, most recent first
+	ListAuditEvents(ctx context.Context, searchParameters *AuditEventSearchParameters) (*models.PaginatedResponse[*AuditEvent], error)
+}
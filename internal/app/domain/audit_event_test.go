@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	organizationID := uuid.New()
+	ctx := ContextWithPrincipal(ContextWithRequestID(context.Background(), "request-1"), &Principal{
+		ID:             "user-1",
+		Type:           PrincipalTypeUser,
+		OrganizationID: organizationID,
+	})
+	farm := &Farm{ID: uuid.New(), Name: "Sunny Farm", LandArea: 10, UnitMeasure: UnitMeasureHectares}
+
+	event, err := NewAuditEvent(ctx, AuditActionDelete, AuditResourceFarm, farm.ID.String(), farm, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+	assert.Equal(t, organizationID, event.OrganizationID)
+	assert.Equal(t, "user-1", event.ActorID)
+	assert.Equal(t, PrincipalTypeUser, event.ActorType)
+	assert.Equal(t, "request-1", event.RequestID)
+	assert.Equal(t, AuditActionDelete, event.Action)
+	assert.Equal(t, AuditResourceFarm, event.ResourceType)
+	assert.Equal(t, farm.ID.String(), event.ResourceID)
+	assert.Contains(t, string(event.Before), `"name":"Sunny Farm"`)
+	assert.Nil(t, event.After)
+	assert.False(t, event.CreatedAt.IsZero())
+}
+
+func TestNewAuditEventWithoutPrincipal(t *testing.T) {
+	event, err := NewAuditEvent(context.Background(), AuditActionCreate, AuditResourceCropProduction, "crop-1", nil, map[string]string{"crop_type": "RICE"})
+	require.NoError(t, err)
+	assert.Empty(t, event.ActorID)
+	assert.Empty(t, event.RequestID)
+	assert.Nil(t, event.Before)
+	assert.JSONEq(t, `{"crop_type":"RICE"}`, string(event.After))
+}
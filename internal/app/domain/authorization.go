@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+)
+
+var ErrInvalidRolePermissions = errors.New("invalid role permissions")
+
+type Permission string
+
+const (
+	PermissionReadFarms    Permission = "farms:read"
+	PermissionCreateFarms  Permission = "farms:create"
+	PermissionUpdateFarms  Permission = "farms:update"
+	PermissionDeleteFarms  Permission = "farms:delete"
+	PermissionRestoreFarms Permission = "farms:restore"
+	PermissionPurgeFarms   Permission = "farms:purge"
+
+	PermissionReadAuditEvents Permission = "audit_events:read"
+
+	PermissionManageLogs Permission = "logs:manage"
+)
+
+func Permissions() []Permission {
+	return []Permission{
+		PermissionReadFarms,
+		PermissionCreateFarms,
+		PermissionUpdateFarms,
+		PermissionDeleteFarms,
+		PermissionRestoreFarms,
+		PermissionPurgeFarms,
+		PermissionReadAuditEvents,
+		PermissionManageLogs,
+	}
+}
+
+func (p Permission) IsValid() bool {
+	for _, permission := range Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// DefaultRolePermissions lists the permissions granted to each role unless they are configured otherwise
+func DefaultRolePermissions() map[Role][]Permission {
+	return map[Role][]Permission{
+		RoleViewer: {PermissionReadFarms},
+		RoleEditor: {PermissionReadFarms, PermissionCreateFarms, PermissionUpdateFarms},
+		RoleAdmin:  Permissions(),
+	}
+}
+
+// ParseRolePermissions parses a semicolon separated list of roles and their comma separated permissions,
+// "*" grants every permission. e.g. "viewer=farms:read;auditor=farms:read,farms:restore;admin=*"
+func ParseRolePermissions(value string) (map[Role][]Permission, error) {
+	rolePermissions := make(map[Role][]Permission)
+	for _, definition := range strings.Split(value, ";") {
+		if strings.TrimSpace(definition) == "" {
+			continue
+		}
+		rawRole, rawPermissions, found := strings.Cut(definition, "=")
+		role := Role(strings.TrimSpace(rawRole))
+		if !found || role == "" {
+			return nil, fmt.Errorf("%w: %q must be a role=permissions pair", ErrInvalidRolePermissions, definition)
+		}
+		permissions := []Permission{}
+		for _, rawPermission := range strings.Split(rawPermissions, ",") {
+			permission := Permission(strings.TrimSpace(rawPermission))
+			switch {
+			case permission == "":
+				continue
+			case permission == "*":
+				permissions = append(permissions, Permissions()...)
+			case permission.IsValid():
+				permissions = append(permissions, permission)
+			default:
+				return nil, fmt.Errorf("%w: unknown permission %q of role %q", ErrInvalidRolePermissions, permission, role)
+			}
+		}
+		rolePermissions[role] = permissions
+	}
+	return rolePermissions, nil
+}
+
+// AuthorizationPolicy is checked by the use cases before running, so the same rules apply to every caller
+type AuthorizationPolicy interface {
+	// Authorize returns a ForbiddenError when the principal of the context isn't granted the permission
+	Authorize(ctx context.Context, permission Permission) error
+}
+
+// RolePolicy grants the principals the permissions of their roles
+type RolePolicy struct {
+	rolePermissions map[Role]map[Permission]bool
+}
+
+func (p *RolePolicy) Authorize(ctx context.Context, permission Permission) error {
+	principal := PrincipalFromContext(ctx)
+	if principal != nil {
+		for _, role := range principal.Roles {
+			if p.rolePermissions[role][permission] {
+				return nil
+			}
+		}
+	}
+	return &shared.ForbiddenError{Permission: string(permission)}
+}
+
+func NewRolePolicy(rolePermissions map[Role][]Permission) *RolePolicy {
+	policy := &RolePolicy{
+		rolePermissions: make(map[Role]map[Permission]bool, len(rolePermissions)),
+	}
+	for role, permissions := range rolePermissions {
+		policy.rolePermissions[role] = make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			policy.rolePermissions[role][permission] = true
+		}
+	}
+	return policy
+}
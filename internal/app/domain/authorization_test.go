@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRolePolicy(t *testing.T) {
+	policy := NewRolePolicy(DefaultRolePermissions())
+	granted := map[Role][]Permission{
+		RoleViewer: {PermissionReadFarms},
+		RoleEditor: {PermissionReadFarms, PermissionCreateFarms, PermissionUpdateFarms},
+		RoleAdmin:  Permissions(),
+	}
+	for role, permissions := range granted {
+		ctx := ContextWithPrincipal(context.Background(), &Principal{ID: "caller", Roles: []Role{role}})
+		for _, permission := range Permissions() {
+			err := policy.Authorize(ctx, permission)
+			if contains(permissions, permission) {
+				assert.NoError(t, err, "%s should be granted %s", role, permission)
+			} else {
+				assert.EqualError(t, err, "permission "+string(permission)+" is required", "%s should be denied %s", role, permission)
+			}
+		}
+	}
+}
+
+func contains(permissions []Permission, permission Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRolePolicyDeniesAnonymousAndUnknownRoles(t *testing.T) {
+	policy := NewRolePolicy(DefaultRolePermissions())
+
+	err := policy.Authorize(context.Background(), PermissionReadFarms)
+	assert.IsType(t, &shared.ForbiddenError{}, err)
+
+	ctx := ContextWithPrincipal(context.Background(), &Principal{ID: "caller", Roles: []Role{"owner"}})
+	err = policy.Authorize(ctx, PermissionReadFarms)
+	assert.IsType(t, &shared.ForbiddenError{}, err)
+}
+
+func TestRolePolicyCombinesRoles(t *testing.T) {
+	policy := NewRolePolicy(map[Role][]Permission{
+		"auditor":  {PermissionReadFarms},
+		"restorer": {PermissionRestoreFarms},
+	})
+	ctx := ContextWithPrincipal(context.Background(), &Principal{ID: "caller", Roles: []Role{"auditor", "restorer"}})
+
+	assert.NoError(t, policy.Authorize(ctx, PermissionReadFarms))
+	assert.NoError(t, policy.Authorize(ctx, PermissionRestoreFarms))
+	assert.Error(t, policy.Authorize(ctx, PermissionDeleteFarms))
+}
+
+func TestParseRolePermissions(t *testing.T) {
+	rolePermissions, err := ParseRolePermissions("viewer=farms:read; auditor=farms:read, farms:restore;admin=*;nobody=")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[Role][]Permission{
+		"viewer":  {PermissionReadFarms},
+		"auditor": {PermissionReadFarms, PermissionRestoreFarms},
+		"admin":   Permissions(),
+		"nobody":  {},
+	}, rolePermissions)
+}
+
+func TestParseInvalidRolePermissions(t *testing.T) {
+	for _, value := range []string{"viewer", "=farms:read", "viewer=farms:write"} {
+		rolePermissions, err := ParseRolePermissions(value)
+
+		assert.Nil(t, rolePermissions)
+		assert.ErrorIs(t, err, ErrInvalidRolePermissions)
+	}
+}
@@ -63,3 +63,27 @@ func NewCropProduction(
 		IsInsured:   isInsured,
 	}, nil
 }
+
+// CropProductionPatch describes a partial crop production update, nil fields are left untouched.
+type CropProductionPatch struct {
+	CropType    *CropType
+	IsIrrigated *bool
+	IsInsured   *bool
+}
+
+// Apply returns a new crop production with the patch applied, the result goes through NewCropProduction validation.
+func (p CropProductionPatch) Apply(cropProduction CropProduction) (*CropProduction, error) {
+	cropType := CropType(cropProduction.CropType)
+	if p.CropType != nil {
+		cropType = *p.CropType
+	}
+	isIrrigated := cropProduction.IsIrrigated
+	if p.IsIrrigated != nil {
+		isIrrigated = *p.IsIrrigated
+	}
+	isInsured := cropProduction.IsInsured
+	if p.IsInsured != nil {
+		isInsured = *p.IsInsured
+	}
+	return NewCropProduction(cropProduction.ID, cropProduction.FarmID, cropType, isIrrigated, isInsured)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+)
+
+type CropProductionRepository interface {
+	ListCropProductions(ctx context.Context, farmId string) ([]CropProduction, error)
+	GetCropProduction(ctx context.Context, farmId string, cropProductionId string) (*CropProduction, error)
+	CreateCropProduction(ctx context.Context, cropProduction *CropProduction) (*CropProduction, error)
+	UpdateCropProduction(ctx context.Context, cropProduction *CropProduction) (*CropProduction, error)
+	// PatchCropProduction applies the patch to the stored crop production and stores the result in a single transaction
+	PatchCropProduction(ctx context.Context, farmId string, cropProductionId string, patch CropProductionPatch) (*CropProduction, error)
+	DeleteCropProduction(ctx context.Context, farmId string, cropProductionId string) error
+}
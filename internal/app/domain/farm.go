@@ -8,9 +8,10 @@ import (
 
 type Farm struct {
 	ID              uuid.UUID        `json:"id"`
+	OrganizationID  uuid.UUID        `json:"organization_id"`
 	Name            string           `json:"name"`
 	LandArea        float64          `json:"land_area"`
-	UnitMeasure     string           `json:"unit_measure"`
+	UnitMeasure     UnitMeasure      `json:"unit_measure"`
 	Address         string           `json:"address"`
 	CreatedAt       time.Time        `json:"created_at"`
 	UpdatedAt       time.Time        `json:"updated_at"`
@@ -18,18 +19,95 @@ type Farm struct {
 	CropProductions []CropProduction `json:"crop_productions"`
 }
 
+// LandAreaInHectares returns the farm land area converted to hectares
+func (f *Farm) LandAreaInHectares() float64 {
+	return f.UnitMeasure.ToHectares(f.LandArea)
+}
+
+// ConvertLandArea expresses the farm land area in the given unit
+func (f *Farm) ConvertLandArea(unit UnitMeasure) {
+	if !f.UnitMeasure.IsValid() || !unit.IsValid() {
+		return
+	}
+	f.LandArea = unit.FromHectares(f.LandAreaInHectares())
+	f.UnitMeasure = unit
+}
+
+// CropTypeMatch defines how a farm is matched against several crop types
+type CropTypeMatch string
+
+const (
+	// CropTypeMatchAny matches farms producing at least one of the crop types
+	CropTypeMatchAny CropTypeMatch = "any"
+	// CropTypeMatchAll matches farms producing every one of the crop types
+	CropTypeMatchAll CropTypeMatch = "all"
+)
+
+func (m CropTypeMatch) IsValid() bool {
+	switch m {
+	case CropTypeMatchAny, CropTypeMatchAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// FarmSearchParameters holds the farm listing filters, pagination and sorting.
+// The land area range is expressed in LandAreaUnit, or in hectares when it is empty.
 type FarmSearchParameters struct {
-	CropType        *string  `json:"crop_type"`
-	MinimumLandArea *float64 `json:"minimum_land_area"`
-	MaximumLandArea *float64 `json:"maximum_land_area"`
-	Page            int      `json:"page"`
-	PerPage         int      `json:"per_page"`
+	Name            *string       `json:"name"`
+	Address         *string       `json:"address"`
+	CropTypes       []CropType    `json:"crop_types"`
+	CropTypeMatch   CropTypeMatch `json:"crop_type_match"`
+	IsIrrigated     *bool         `json:"is_irrigated"`
+	IsInsured       *bool         `json:"is_insured"`
+	UnitMeasure     *UnitMeasure  `json:"unit_measure"`
+	MinimumLandArea *float64      `json:"minimum_land_area"`
+	MaximumLandArea *float64      `json:"maximum_land_area"`
+	LandAreaUnit    UnitMeasure   `json:"land_area_unit"`
+	CreatedAfter    *time.Time    `json:"created_after"`
+	CreatedBefore   *time.Time    `json:"created_before"` // exclusive, farms created at this time are not matched
+	IncludeDeleted  bool          `json:"include_deleted"`
+	OnlyDeleted     bool          `json:"only_deleted"`
+	Sort            []FarmSort    `json:"sort"`
+	Page            int           `json:"page"`
+	PerPage         int           `json:"per_page"`
+	Cursor          *FarmCursor   `json:"cursor"`
+	Limit           int           `json:"limit"`
+}
+
+// FarmPatch describes a partial farm update, nil fields are left untouched.
+// When CropProductions is set it replaces the whole crop production list.
+type FarmPatch struct {
+	Name            *string
+	LandArea        *float64
+	UnitMeasure     *UnitMeasure
+	Address         *string
+	CropProductions *[]CropProduction
+}
+
+func (p FarmPatch) Apply(farm *Farm) {
+	if p.Name != nil {
+		farm.Name = *p.Name
+	}
+	if p.LandArea != nil {
+		farm.LandArea = *p.LandArea
+	}
+	if p.UnitMeasure != nil {
+		farm.UnitMeasure = *p.UnitMeasure
+	}
+	if p.Address != nil {
+		farm.Address = *p.Address
+	}
+	if p.CropProductions != nil {
+		farm.CropProductions = *p.CropProductions
+	}
 }
 
 func NewFarm(
 	name string,
 	landArea float64,
-	unitMeasure string,
+	unitMeasure UnitMeasure,
 	address string,
 	productions []CropProduction,
 ) (*Farm, error) {
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFarmCursorRoundTrip(t *testing.T) {
+	cursor := FarmCursor{CreatedAt: time.Now().UTC(), ID: uuid.New()}
+
+	decoded, err := DecodeFarmCursor(cursor.Encode())
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+}
+
+func TestDecodeInvalidFarmCursor(t *testing.T) {
+	for _, encoded := range []string{"", "not-a-cursor", FarmCursor{}.Encode()} {
+		cursor, err := DecodeFarmCursor(encoded)
+
+		assert.Nil(t, cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	}
+}
@@ -8,6 +8,15 @@ import (
 
 type FarmRepository interface {
 	CreateFarm(ctx context.Context, farm *Farm) (*Farm, error)
+	CreateFarms(ctx context.Context, farms []*Farm) ([]*Farm, error)
+	ListFarmsByCursor(ctx context.Context, searchParameters *FarmSearchParameters) (*models.CursorPaginatedResponse[*Farm], error)
+	GetFarmByID(ctx context.Context, farmId string) (*Farm, error)
 	ListFarms(ctx context.Context, searchParameters *FarmSearchParameters) (*models.PaginatedResponse[*Farm], error)
+	GetFarmStats(ctx context.Context, searchParameters *FarmSearchParameters) (*FarmStats, error)
+	UpdateFarm(ctx context.Context, farm *Farm) (*Farm, error)
+	// PatchFarm applies the patch to the stored farm and stores the result in a single transaction
+	PatchFarm(ctx context.Context, farmId string, patch FarmPatch) (*Farm, error)
 	DeleteFarm(ctx context.Context, farmId string) error
+	RestoreFarm(ctx context.Context, farmId string) (*Farm, error)
+	PurgeFarm(ctx context.Context, farmId string) error
 }
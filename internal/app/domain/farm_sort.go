@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+type FarmSortField string
+
+const (
+	FarmSortByName      FarmSortField = "name"
+	FarmSortByLandArea  FarmSortField = "land_area"
+	FarmSortByCreatedAt FarmSortField = "created_at"
+	FarmSortByUpdatedAt FarmSortField = "updated_at"
+)
+
+func (f FarmSortField) IsValid() bool {
+	switch f {
+	case FarmSortByName, FarmSortByLandArea, FarmSortByCreatedAt, FarmSortByUpdatedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+type FarmSort struct {
+	Field      FarmSortField `json:"field"`
+	Descending bool          `json:"descending"`
+}
+
+// ParseFarmSort parses a comma separated list of sort fields, fields prefixed with "-" are sorted in descending order.
+// e.g. "-land_area,name"
+func ParseFarmSort(value string) ([]FarmSort, error) {
+	var sorts []FarmSort
+	seenFields := make(map[FarmSortField]bool)
+	for _, rawField := range strings.Split(value, ",") {
+		rawField = strings.TrimSpace(rawField)
+		sort := FarmSort{}
+		if strings.HasPrefix(rawField, "-") {
+			sort.Descending = true
+			rawField = strings.TrimPrefix(rawField, "-")
+		}
+		sort.Field = FarmSortField(rawField)
+		if !sort.Field.IsValid() || seenFields[sort.Field] {
+			return nil, ErrInvalidSort
+		}
+		seenFields[sort.Field] = true
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFarmSort(t *testing.T) {
+	sorts, err := ParseFarmSort("-land_area, name")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []FarmSort{
+		{Field: FarmSortByLandArea, Descending: true},
+		{Field: FarmSortByName},
+	}, sorts)
+}
+
+func TestParseInvalidFarmSort(t *testing.T) {
+	for _, value := range []string{"", "address", "name,-name", "--name"} {
+		sorts, err := ParseFarmSort(value)
+
+		assert.Nil(t, sorts)
+		assert.ErrorIs(t, err, ErrInvalidSort)
+	}
+}
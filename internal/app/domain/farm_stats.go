@@ -0,0 +1,39 @@
+package domain
+
+// CropTypeStats aggregates the crop productions of a crop type
+type CropTypeStats struct {
+	CropType            string  `json:"crop_type"`
+	CropProductionCount int64   `json:"crop_production_count"`
+	FarmCount           int64   `json:"farm_count"`
+	IrrigatedRatio      float64 `json:"irrigated_ratio"`
+	InsuredRatio        float64 `json:"insured_ratio"`
+}
+
+// UnitMeasureStats aggregates the farms measured in a unit, land areas are expressed in that unit
+type UnitMeasureStats struct {
+	UnitMeasure     UnitMeasure     `json:"unit_measure"`
+	FarmCount       int64           `json:"farm_count"`
+	TotalLandArea   float64         `json:"total_land_area"`
+	AverageLandArea float64         `json:"average_land_area"`
+	CropTypes       []CropTypeStats `json:"crop_types"`
+}
+
+// FarmStats aggregates the farms matching a search, the overall land areas are expressed in LandAreaUnit
+type FarmStats struct {
+	FarmCount       int64              `json:"farm_count"`
+	LandAreaUnit    UnitMeasure        `json:"land_area_unit"`
+	TotalLandArea   float64            `json:"total_land_area"`
+	AverageLandArea float64            `json:"average_land_area"`
+	CropTypes       []CropTypeStats    `json:"crop_types"`
+	UnitMeasures    []UnitMeasureStats `json:"unit_measures"`
+}
+
+// ConvertLandArea expresses the overall land areas in the given unit
+func (s *FarmStats) ConvertLandArea(unit UnitMeasure) {
+	if !s.LandAreaUnit.IsValid() || !unit.IsValid() {
+		return
+	}
+	s.TotalLandArea = unit.FromHectares(s.LandAreaUnit.ToHectares(s.TotalLandArea))
+	s.AverageLandArea = unit.FromHectares(s.LandAreaUnit.ToHectares(s.AverageLandArea))
+	s.LandAreaUnit = unit
+}
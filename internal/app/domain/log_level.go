@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var ErrInvalidLogLevel = errors.New("the log level must be one of debug, info, warn or error")
+
+// LogLevelManager reads and changes the level of the application logs while the server runs
+type LogLevelManager interface {
+	Level() string
+	SetLevel(level string) error
+}
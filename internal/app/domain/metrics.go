@@ -0,0 +1,8 @@
+package domain
+
+// MetricsRecorder counts the domain events exposed as application metrics, such as the farms created
+type MetricsRecorder interface {
+	FarmCreated()
+	FarmDeleted()
+	CropProductionCreated(cropType string)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrMissingOrganization is returned when the caller of a tenant scoped operation doesn't belong to an organization
+var ErrMissingOrganization = errors.New("the caller doesn't belong to an organization")
+
+type contextKey string
+
+// PrincipalContextKey is the context key holding the authenticated Principal of a request
+const PrincipalContextKey contextKey = "principal"
+
+type PrincipalType string
+
+const (
+	PrincipalTypeAPIKey PrincipalType = "api_key"
+	PrincipalTypeUser   PrincipalType = "user"
+)
+
+// Principal is the identity of the caller, either an API key or the subject of a JWT bearer token.
+// Callers only see the farms of their organization.
+type Principal struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Type           PrincipalType `json:"type"`
+	Roles          []Role        `json:"roles"`
+	OrganizationID uuid.UUID     `json:"organization_id"`
+}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, PrincipalContextKey, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal of the context, or nil for anonymous contexts
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(PrincipalContextKey).(*Principal)
+	return principal
+}
+
+// OrganizationIDFromContext returns the organization of the context principal, or ErrMissingOrganization
+// for anonymous contexts and principals without organization
+func OrganizationIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	principal := PrincipalFromContext(ctx)
+	if principal == nil || principal.OrganizationID == uuid.Nil {
+		return uuid.Nil, ErrMissingOrganization
+	}
+	return principal.OrganizationID, nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+// RequestIDContextKey is the context key holding the ID of the request, set by the request ID middleware
+const RequestIDContextKey contextKey = "request_id"
+
+// RouteContextKey is the context key holding the route template of the request, such as /farms/:id
+const RouteContextKey contextKey = "route"
+
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDContextKey, requestID)
+}
+
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, RouteContextKey, route)
+}
+
+// RequestIDFromContext returns the ID of the request of the context, or an empty string outside of requests
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// RouteFromContext returns the route template of the request of the context, or an empty string outside of requests
+func RouteFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(RouteContextKey).(string)
+	return route
+}
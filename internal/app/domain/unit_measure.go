@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidUnitMeasure = errors.New("invalid unit measure")
+
+// UnitMeasure is the unit of a farm land area
+type UnitMeasure string
+
+const (
+	UnitMeasureHectares          UnitMeasure = "hectares"
+	UnitMeasureAcres             UnitMeasure = "acres"
+	UnitMeasureSquareMeters      UnitMeasure = "square_meters"
+	UnitMeasureSquareKilometers  UnitMeasure = "square_kilometers"
+	UnitMeasureAlqueiresPaulista UnitMeasure = "alqueires_paulista"
+	UnitMeasureAlqueiresMineiro  UnitMeasure = "alqueires_mineiro"
+)
+
+// hectaresPerUnit holds how many hectares one unit of each unit measure is worth
+var hectaresPerUnit = map[UnitMeasure]float64{
+	UnitMeasureHectares:          1,
+	UnitMeasureAcres:             0.40468564224,
+	UnitMeasureSquareMeters:      0.0001,
+	UnitMeasureSquareKilometers:  100,
+	UnitMeasureAlqueiresPaulista: 2.42,
+	UnitMeasureAlqueiresMineiro:  4.84,
+}
+
+// unitMeasureAliases maps the common spellings of the unit measures to their canonical value,
+// a plain "alqueire" is read as the alqueire paulista
+var unitMeasureAliases = map[string]UnitMeasure{
+	"ha":                UnitMeasureHectares,
+	"hectare":           UnitMeasureHectares,
+	"ac":                UnitMeasureAcres,
+	"acre":              UnitMeasureAcres,
+	"m2":                UnitMeasureSquareMeters,
+	"m²":                UnitMeasureSquareMeters,
+	"square_meter":      UnitMeasureSquareMeters,
+	"km2":               UnitMeasureSquareKilometers,
+	"km²":               UnitMeasureSquareKilometers,
+	"square_kilometer":  UnitMeasureSquareKilometers,
+	"alqueire":          UnitMeasureAlqueiresPaulista,
+	"alqueires":         UnitMeasureAlqueiresPaulista,
+	"alqueire_paulista": UnitMeasureAlqueiresPaulista,
+	"alqueire_mineiro":  UnitMeasureAlqueiresMineiro,
+}
+
+func (u UnitMeasure) IsValid() bool {
+	_, exists := hectaresPerUnit[u]
+	return exists
+}
+
+func (u UnitMeasure) String() string {
+	return string(u)
+}
+
+// ToHectares converts an area in this unit to hectares
+func (u UnitMeasure) ToHectares(area float64) float64 {
+	return area * hectaresPerUnit[u]
+}
+
+// FromHectares converts an area in hectares to this unit
+func (u UnitMeasure) FromHectares(area float64) float64 {
+	return area / hectaresPerUnit[u]
+}
+
+// ParseUnitMeasure returns the canonical unit measure for the given value, ignoring its case and common aliases.
+// e.g. "ha", "Hectare" and "hectares" are all parsed as UnitMeasureHectares
+func ParseUnitMeasure(value string) (UnitMeasure, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+	unit := UnitMeasure(normalized)
+	if alias, exists := unitMeasureAliases[normalized]; exists {
+		unit = alias
+	}
+	if !unit.IsValid() {
+		return "", ErrInvalidUnitMeasure
+	}
+	return unit, nil
+}
+
+// UnitMeasures lists the supported unit measures
+func UnitMeasures() []UnitMeasure {
+	return []UnitMeasure{
+		UnitMeasureHectares,
+		UnitMeasureAcres,
+		UnitMeasureSquareMeters,
+		UnitMeasureSquareKilometers,
+		UnitMeasureAlqueiresPaulista,
+		UnitMeasureAlqueiresMineiro,
+	}
+}
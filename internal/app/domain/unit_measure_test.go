@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUnitMeasure(t *testing.T) {
+	tests := map[string]UnitMeasure{
+		"hectares":          UnitMeasureHectares,
+		" HA ":              UnitMeasureHectares,
+		"Acre":              UnitMeasureAcres,
+		"m²":                UnitMeasureSquareMeters,
+		"Square Kilometers": UnitMeasureSquareKilometers,
+		"alqueire":          UnitMeasureAlqueiresPaulista,
+		"alqueire-mineiro":  UnitMeasureAlqueiresMineiro,
+	}
+	for value, expected := range tests {
+		unit, err := ParseUnitMeasure(value)
+
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, unit, value)
+	}
+}
+
+func TestParseInvalidUnitMeasure(t *testing.T) {
+	for _, value := range []string{"", "feet", "hectaress"} {
+		unit, err := ParseUnitMeasure(value)
+
+		assert.Empty(t, unit)
+		assert.ErrorIs(t, err, ErrInvalidUnitMeasure)
+	}
+}
+
+func TestFarmConvertLandArea(t *testing.T) {
+	farm := &Farm{LandArea: 2, UnitMeasure: UnitMeasureAlqueiresPaulista}
+
+	assert.InDelta(t, 4.84, farm.LandAreaInHectares(), 1e-9)
+
+	farm.ConvertLandArea(UnitMeasureSquareMeters)
+	assert.Equal(t, UnitMeasureSquareMeters, farm.UnitMeasure)
+	assert.InDelta(t, 48400, farm.LandArea, 1e-6)
+}
+
+func TestFarmConvertLandAreaKeepsUnknownUnits(t *testing.T) {
+	farm := &Farm{LandArea: 10, UnitMeasure: "legacy"}
+
+	farm.ConvertLandArea(UnitMeasureAcres)
+
+	assert.Equal(t, UnitMeasure("legacy"), farm.UnitMeasure)
+	assert.Equal(t, float64(10), farm.LandArea)
+}
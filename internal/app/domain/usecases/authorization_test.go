@@ -0,0 +1,192 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/google/uuid"
+	"github.com/tj/assert"
+)
+
+// denyingPolicy records the permission checked by a use case and denies it
+type denyingPolicy struct {
+	permission domain.Permission
+}
+
+func (p *denyingPolicy) Authorize(ctx context.Context, permission domain.Permission) error {
+	p.permission = permission
+	return &shared.ForbiddenError{Permission: string(permission)}
+}
+
+func TestUseCasesRequirePermissions(t *testing.T) {
+	ctx := context.Background()
+	farmId := uuid.NewString()
+	tests := []struct {
+		name               string
+		execute            func(policy domain.AuthorizationPolicy) error
+		expectedPermission domain.Permission
+	}{
+		{
+			name: "CreateFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewCreateFarmUseCase(new(mockFarmRepository), policy, new(countingMetricsRecorder)).Execute(ctx, domain.Farm{})
+				return err
+			},
+			expectedPermission: domain.PermissionCreateFarms,
+		},
+		{
+			name: "ImportFarms",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, _, err := NewImportFarmsUseCase(new(mockFarmRepository), policy, new(countingMetricsRecorder)).Execute(ctx, []domain.Farm{{}}, true)
+				return err
+			},
+			expectedPermission: domain.PermissionCreateFarms,
+		},
+		{
+			name: "ListFarms",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewListFarmsUseCase(new(mockFarmRepository), policy).Execute(ctx, &domain.FarmSearchParameters{})
+				return err
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "ListFarmsByCursor",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewListFarmsByCursorUseCase(new(mockFarmRepository), policy).Execute(ctx, &domain.FarmSearchParameters{})
+				return err
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "ExportFarms",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				return NewExportFarmsUseCase(new(mockFarmRepository), policy).Execute(ctx, &domain.FarmSearchParameters{}, 10, func([]*domain.Farm) error { return nil })
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "GetFarmStats",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewGetFarmStatsUseCase(new(mockFarmRepository), policy).Execute(ctx, &domain.FarmSearchParameters{})
+				return err
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "GetFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewGetFarmUseCase(new(mockFarmRepository), policy).Execute(ctx, farmId)
+				return err
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "UpdateFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewUpdateFarmUseCase(new(mockFarmRepository), policy).Execute(ctx, domain.Farm{})
+				return err
+			},
+			expectedPermission: domain.PermissionUpdateFarms,
+		},
+		{
+			name: "PatchFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewPatchFarmUseCase(new(mockFarmRepository), policy).Execute(ctx, farmId, domain.FarmPatch{})
+				return err
+			},
+			expectedPermission: domain.PermissionUpdateFarms,
+		},
+		{
+			name: "DeleteFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				return NewDeleteFarmUseCase(new(mockFarmRepository), policy, new(countingMetricsRecorder)).Execute(ctx, farmId)
+			},
+			expectedPermission: domain.PermissionDeleteFarms,
+		},
+		{
+			name: "RestoreFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewRestoreFarmUseCase(new(mockFarmRepository), policy).Execute(ctx, farmId)
+				return err
+			},
+			expectedPermission: domain.PermissionRestoreFarms,
+		},
+		{
+			name: "PurgeFarm",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				return NewPurgeFarmUseCase(new(mockFarmRepository), policy).Execute(ctx, farmId)
+			},
+			expectedPermission: domain.PermissionPurgeFarms,
+		},
+		{
+			name: "ListCropProductions",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewListCropProductionsUseCase(new(mockCropProductionRepository), policy).Execute(ctx, farmId)
+				return err
+			},
+			expectedPermission: domain.PermissionReadFarms,
+		},
+		{
+			name: "CreateCropProduction",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewCreateCropProductionUseCase(new(mockCropProductionRepository), policy, new(countingMetricsRecorder)).Execute(ctx, uuid.New(), "RICE", false, false)
+				return err
+			},
+			expectedPermission: domain.PermissionUpdateFarms,
+		},
+		{
+			name: "PatchCropProduction",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewPatchCropProductionUseCase(new(mockCropProductionRepository), policy).Execute(ctx, farmId, uuid.NewString(), domain.CropProductionPatch{})
+				return err
+			},
+			expectedPermission: domain.PermissionUpdateFarms,
+		},
+		{
+			name: "DeleteCropProduction",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				return NewDeleteCropProductionUseCase(new(mockCropProductionRepository), policy).Execute(ctx, farmId, uuid.NewString())
+			},
+			expectedPermission: domain.PermissionUpdateFarms,
+		},
+		{
+			name: "ListAuditEvents",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewListAuditEventsUseCase(new(mockAuditEventRepository), policy).Execute(ctx, &domain.AuditEventSearchParameters{})
+				return err
+			},
+			expectedPermission: domain.PermissionReadAuditEvents,
+		},
+		{
+			name: "GetLogLevel",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				_, err := NewGetLogLevelUseCase(new(mockLogLevelManager), policy).Execute(ctx)
+				return err
+			},
+			expectedPermission: domain.PermissionManageLogs,
+		},
+		{
+			name: "SetLogLevel",
+			execute: func(policy domain.AuthorizationPolicy) error {
+				return NewSetLogLevelUseCase(new(mockLogLevelManager), policy).Execute(ctx, "debug")
+			},
+			expectedPermission: domain.PermissionManageLogs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &denyingPolicy{}
+			// the repositories mocks have no expectations, so the test fails if a denied use case reaches them
+			err := tt.execute(policy)
+
+			var forbiddenError *shared.ForbiddenError
+			assert.True(t, errors.As(err, &forbiddenError))
+			assert.Equal(t, string(tt.expectedPermission), forbiddenError.Permission)
+			assert.Equal(t, tt.expectedPermission, policy.permission)
+		})
+	}
+}
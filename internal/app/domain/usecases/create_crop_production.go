@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+type CreateCropProductionUseCase interface {
+	Execute(ctx context.Context, farmId uuid.UUID, cropType domain.CropType, isIrrigated bool, isInsured bool) (*domain.CropProduction, error)
+}
+type CreateCropProduction struct {
+	repository domain.CropProductionRepository
+	policy     domain.AuthorizationPolicy
+	metrics    domain.MetricsRecorder
+}
+
+func (uc *CreateCropProduction) Execute(
+	ctx context.Context,
+	farmId uuid.UUID,
+	cropType domain.CropType,
+	isIrrigated bool,
+	isInsured bool,
+) (*domain.CropProduction, error) {
+	ctx, span := startSpan(ctx, "CreateCropProduction")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionUpdateFarms); err != nil {
+		return nil, err
+	}
+	cropProduction, err := domain.NewCropProduction(uuid.New(), farmId, cropType, isIrrigated, isInsured)
+	if err != nil {
+		return nil, err
+	}
+	newCropProduction, err := uc.repository.CreateCropProduction(ctx, cropProduction)
+	if err != nil {
+		return nil, err
+	}
+	uc.metrics.CropProductionCreated(newCropProduction.CropType)
+	return newCropProduction, nil
+}
+
+func NewCreateCropProductionUseCase(repo domain.CropProductionRepository, policy domain.AuthorizationPolicy, metrics domain.MetricsRecorder) *CreateCropProduction {
+	return &CreateCropProduction{
+		repository: repo,
+		policy:     policy,
+		metrics:    metrics,
+	}
+}
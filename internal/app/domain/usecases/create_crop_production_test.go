@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+type mockCropProductionRepository struct {
+	mock.Mock
+}
+
+func (m *mockCropProductionRepository) ListCropProductions(ctx context.Context, farmId string) ([]domain.CropProduction, error) {
+	panic("unimplemented")
+}
+
+func (m *mockCropProductionRepository) GetCropProduction(ctx context.Context, farmId string, cropProductionId string) (*domain.CropProduction, error) {
+	args := m.Called(ctx, farmId, cropProductionId)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+func (m *mockCropProductionRepository) CreateCropProduction(ctx context.Context, cropProduction *domain.CropProduction) (*domain.CropProduction, error) {
+	args := m.Called(ctx, cropProduction)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+func (m *mockCropProductionRepository) UpdateCropProduction(ctx context.Context, cropProduction *domain.CropProduction) (*domain.CropProduction, error) {
+	args := m.Called(ctx, cropProduction)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+func (m *mockCropProductionRepository) PatchCropProduction(ctx context.Context, farmId string, cropProductionId string, patch domain.CropProductionPatch) (*domain.CropProduction, error) {
+	args := m.Called(ctx, farmId, cropProductionId, patch)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+func (m *mockCropProductionRepository) DeleteCropProduction(ctx context.Context, farmId string, cropProductionId string) error {
+	panic("unimplemented")
+}
+
+func TestCreateCropProductionSuccess(t *testing.T) {
+	mockRepo := new(mockCropProductionRepository)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewCreateCropProductionUseCase(mockRepo, allowAllPolicy{}, metrics)
+
+	ctx := context.Background()
+	farmId := uuid.New()
+	mockRepo.On("CreateCropProduction", mock.Anything, mock.MatchedBy(func(c *domain.CropProduction) bool {
+		return c.ID != uuid.Nil && c.FarmID == farmId && c.CropType == domain.CropTypeCorn.String() && c.IsIrrigated && !c.IsInsured
+	})).Return(&domain.CropProduction{ID: uuid.New(), FarmID: farmId, CropType: domain.CropTypeCorn.String()}, nil)
+
+	result, err := useCase.Execute(ctx, farmId, domain.CropTypeCorn, true, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, farmId, result.FarmID)
+	assert.Equal(t, map[string]int{domain.CropTypeCorn.String(): 1}, metrics.cropProductions)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCropProductionInvalidCropType(t *testing.T) {
+	mockRepo := new(mockCropProductionRepository)
+	useCase := NewCreateCropProductionUseCase(mockRepo, allowAllPolicy{}, new(countingMetricsRecorder))
+
+	result, err := useCase.Execute(context.Background(), uuid.New(), domain.CropType("BEANS"), true, false)
+
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrInvalidCropType, err)
+	mockRepo.AssertNotCalled(t, "CreateCropProduction", mock.Anything, mock.Anything)
+}
+
+func TestPatchCropProductionInTheRepository(t *testing.T) {
+	mockRepo := new(mockCropProductionRepository)
+	useCase := NewPatchCropProductionUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	patched := &domain.CropProduction{
+		ID:          uuid.New(),
+		FarmID:      uuid.New(),
+		CropType:    domain.CropTypeRice.String(),
+		IsIrrigated: true,
+		IsInsured:   true,
+	}
+	patch := domain.CropProductionPatch{IsInsured: &patched.IsInsured}
+	// the patch is applied by the repository, in the transaction reading the stored crop production
+	mockRepo.On("PatchCropProduction", mock.Anything, patched.FarmID.String(), patched.ID.String(), patch).Return(patched, nil)
+
+	result, err := useCase.Execute(ctx, patched.FarmID.String(), patched.ID.String(), patch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, patched, result)
+	mockRepo.AssertNotCalled(t, "GetCropProduction", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateCropProduction", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
@@ -12,9 +12,27 @@ type CreateFarmUseCase interface {
 }
 type CreateFarm struct {
 	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+	metrics    domain.MetricsRecorder
 }
 
 func (uc *CreateFarm) Execute(ctx context.Context, farm domain.Farm) (*domain.Farm, error) {
+	ctx, span := startSpan(ctx, "CreateFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionCreateFarms); err != nil {
+		return nil, err
+	}
+	assignFarmIDs(&farm)
+	newFarm, err := uc.repository.CreateFarm(ctx, &farm)
+	if err != nil {
+		return nil, err
+	}
+	recordFarmCreated(uc.metrics, newFarm)
+	return newFarm, nil
+}
+
+// assignFarmIDs generates the IDs of a new farm and of its crop productions
+func assignFarmIDs(farm *domain.Farm) {
 	farmID := uuid.New()
 	farm.ID = farmID
 
@@ -22,11 +40,20 @@ func (uc *CreateFarm) Execute(ctx context.Context, farm domain.Farm) (*domain.Fa
 		farm.CropProductions[i].ID = uuid.New()
 		farm.CropProductions[i].FarmID = farmID
 	}
-	return uc.repository.CreateFarm(ctx, &farm)
 }
 
-func NewCreateFarmUseCase(repo domain.FarmRepository) *CreateFarm {
+// recordFarmCreated counts a new farm along with its crop productions
+func recordFarmCreated(metrics domain.MetricsRecorder, farm *domain.Farm) {
+	metrics.FarmCreated()
+	for _, cropProduction := range farm.CropProductions {
+		metrics.CropProductionCreated(cropProduction.CropType)
+	}
+}
+
+func NewCreateFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy, metrics domain.MetricsRecorder) *CreateFarm {
 	return &CreateFarm{
 		repository: repo,
+		policy:     policy,
+		metrics:    metrics,
 	}
 }
@@ -12,11 +12,58 @@ import (
 	"github.com/tj/assert"
 )
 
+// allowAllPolicy grants every permission, the authorization rules are covered by the authorization tests
+type allowAllPolicy struct{}
+
+func (allowAllPolicy) Authorize(ctx context.Context, permission domain.Permission) error {
+	return nil
+}
+
+// countingMetricsRecorder counts the recorded domain events
+type countingMetricsRecorder struct {
+	farmsCreated    int
+	farmsDeleted    int
+	cropProductions map[string]int
+}
+
+func (r *countingMetricsRecorder) FarmCreated() {
+	r.farmsCreated++
+}
+
+func (r *countingMetricsRecorder) FarmDeleted() {
+	r.farmsDeleted++
+}
+
+func (r *countingMetricsRecorder) CropProductionCreated(cropType string) {
+	if r.cropProductions == nil {
+		r.cropProductions = map[string]int{}
+	}
+	r.cropProductions[cropType]++
+}
+
 type mockFarmRepository struct {
 	mock.Mock
 }
 
 func (m *mockFarmRepository) DeleteFarm(ctx context.Context, farmId string) error {
+	return m.Called(ctx, farmId).Error(0)
+}
+
+func (m *mockFarmRepository) GetFarmByID(ctx context.Context, farmId string) (*domain.Farm, error) {
+	args := m.Called(ctx, farmId)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+func (m *mockFarmRepository) UpdateFarm(ctx context.Context, farm *domain.Farm) (*domain.Farm, error) {
+	args := m.Called(ctx, farm)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+func (m *mockFarmRepository) RestoreFarm(ctx context.Context, farmId string) (*domain.Farm, error) {
+	panic("unimplemented")
+}
+
+func (m *mockFarmRepository) PurgeFarm(ctx context.Context, farmId string) error {
 	panic("unimplemented")
 }
 
@@ -24,6 +71,31 @@ func (m *mockFarmRepository) ListFarms(ctx context.Context, searchParameters *do
 	panic("unimplemented")
 }
 
+func (m *mockFarmRepository) CreateFarms(ctx context.Context, farms []*domain.Farm) ([]*domain.Farm, error) {
+	args := m.Called(ctx, farms)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Farm), args.Error(1)
+}
+
+func (m *mockFarmRepository) GetFarmStats(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*domain.FarmStats, error) {
+	panic("unimplemented")
+}
+
+func (m *mockFarmRepository) ListFarmsByCursor(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.CursorPaginatedResponse[*domain.Farm], error) {
+	args := m.Called(ctx, searchParameters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CursorPaginatedResponse[*domain.Farm]), args.Error(1)
+}
+
+func (m *mockFarmRepository) PatchFarm(ctx context.Context, farmId string, patch domain.FarmPatch) (*domain.Farm, error) {
+	args := m.Called(ctx, farmId, patch)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
 func (m *mockFarmRepository) CreateFarm(ctx context.Context, farm *domain.Farm) (*domain.Farm, error) {
 	args := m.Called(ctx, farm)
 	return args.Get(0).(*domain.Farm), args.Error(1)
@@ -31,7 +103,8 @@ func (m *mockFarmRepository) CreateFarm(ctx context.Context, farm *domain.Farm)
 
 func TestCreateFarmSuccess(t *testing.T) {
 	mockRepo := new(mockFarmRepository)
-	useCase := NewCreateFarmUseCase(mockRepo)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewCreateFarmUseCase(mockRepo, allowAllPolicy{}, metrics)
 
 	ctx := context.Background()
 	farm := domain.Farm{
@@ -49,7 +122,7 @@ func TestCreateFarmSuccess(t *testing.T) {
 	expectedFarm.CropProductions[0].ID = uuid.New()
 	expectedFarm.CropProductions[0].FarmID = expectedFarm.ID
 
-	mockRepo.On("CreateFarm", ctx, mock.MatchedBy(func(f *domain.Farm) bool {
+	mockRepo.On("CreateFarm", mock.Anything, mock.MatchedBy(func(f *domain.Farm) bool {
 		return f.Name == farm.Name && f.LandArea == farm.LandArea && len(f.CropProductions) == 1
 	})).Return(&expectedFarm, nil)
 
@@ -58,12 +131,15 @@ func TestCreateFarmSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedFarm.ID, result.ID)
 	assert.Equal(t, expectedFarm.CropProductions[0].ID, result.CropProductions[0].ID)
+	assert.Equal(t, 1, metrics.farmsCreated)
+	assert.Equal(t, map[string]int{"RICE": 1}, metrics.cropProductions)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestCreateFarmRepositoryError(t *testing.T) {
 	mockRepo := new(mockFarmRepository)
-	useCase := NewCreateFarmUseCase(mockRepo)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewCreateFarmUseCase(mockRepo, allowAllPolicy{}, metrics)
 
 	ctx := context.Background()
 	farm := domain.Farm{
@@ -76,12 +152,13 @@ func TestCreateFarmRepositoryError(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("CreateFarm", ctx, mock.Anything).Return((*domain.Farm)(nil), errors.New("database error"))
+	mockRepo.On("CreateFarm", mock.Anything, mock.Anything).Return((*domain.Farm)(nil), errors.New("database error"))
 
 	result, err := useCase.Execute(ctx, farm)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.EqualError(t, err, "database error")
+	assert.Equal(t, 0, metrics.farmsCreated)
 	mockRepo.AssertExpectations(t)
 }
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type DeleteCropProductionUseCase interface {
+	Execute(ctx context.Context, farmId string, cropProductionId string) error
+}
+type DeleteCropProduction struct {
+	repository domain.CropProductionRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *DeleteCropProduction) Execute(ctx context.Context, farmId string, cropProductionId string) error {
+	ctx, span := startSpan(ctx, "DeleteCropProduction")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionUpdateFarms); err != nil {
+		return err
+	}
+	return uc.repository.DeleteCropProduction(ctx, farmId, cropProductionId)
+}
+
+func NewDeleteCropProductionUseCase(repo domain.CropProductionRepository, policy domain.AuthorizationPolicy) *DeleteCropProduction {
+	return &DeleteCropProduction{
+		repository: repo,
+		policy:     policy,
+	}
+}
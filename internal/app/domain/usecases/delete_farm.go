@@ -11,14 +11,27 @@ type DeleteFarmUseCase interface {
 }
 type DeleteFarm struct {
 	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+	metrics    domain.MetricsRecorder
 }
 
 func (uc *DeleteFarm) Execute(ctx context.Context, farmId string) error {
-	return uc.repository.DeleteFarm(ctx, farmId)
+	ctx, span := startSpan(ctx, "DeleteFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionDeleteFarms); err != nil {
+		return err
+	}
+	if err := uc.repository.DeleteFarm(ctx, farmId); err != nil {
+		return err
+	}
+	uc.metrics.FarmDeleted()
+	return nil
 }
 
-func NewDeleteFarmUseCase(repo domain.FarmRepository) *DeleteFarm {
+func NewDeleteFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy, metrics domain.MetricsRecorder) *DeleteFarm {
 	return &DeleteFarm{
 		repository: repo,
+		policy:     policy,
+		metrics:    metrics,
 	}
 }
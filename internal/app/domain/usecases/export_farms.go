@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type ExportFarmsUseCase interface {
+	Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters, batchSize int, handle func(farms []*domain.Farm) error) error
+}
+
+// ExportFarms walks through every farm matching the search filters, handing them in batches of batchSize farms.
+// Batches are read with the cursor pagination so farms created during the export are neither skipped nor repeated
+type ExportFarms struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *ExportFarms) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters, batchSize int, handle func(farms []*domain.Farm) error) error {
+	ctx, span := startSpan(ctx, "ExportFarms")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return err
+	}
+	batchParameters := *searchParameters
+	batchParameters.Limit = batchSize
+	batchParameters.Cursor = nil
+	for {
+		page, err := uc.repository.ListFarmsByCursor(ctx, &batchParameters)
+		if err != nil {
+			return err
+		}
+		if len(page.Items) > 0 {
+			if err := handle(page.Items); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == nil {
+			return nil
+		}
+		cursor, err := domain.DecodeFarmCursor(*page.NextCursor)
+		if err != nil {
+			return err
+		}
+		batchParameters.Cursor = cursor
+	}
+}
+
+func NewExportFarmsUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *ExportFarms {
+	return &ExportFarms{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+func TestExportFarmsWalksThroughEveryBatch(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	useCase := NewExportFarmsUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	name := "Farm"
+	searchParameters := &domain.FarmSearchParameters{Name: &name, Page: 3, PerPage: 50}
+	lastFarm := &domain.Farm{ID: uuid.New(), CreatedAt: time.Now().UTC()}
+	nextCursor := domain.FarmCursor{CreatedAt: lastFarm.CreatedAt, ID: lastFarm.ID}.Encode()
+
+	mockRepo.On("ListFarmsByCursor", mock.Anything, mock.MatchedBy(func(params *domain.FarmSearchParameters) bool {
+		return params.Cursor == nil && params.Limit == 2 && params.Name == &name
+	})).Return(&models.CursorPaginatedResponse[*domain.Farm]{
+		Items:      []*domain.Farm{{ID: uuid.New()}, lastFarm},
+		NextCursor: &nextCursor,
+		Limit:      2,
+	}, nil).Once()
+	mockRepo.On("ListFarmsByCursor", mock.Anything, mock.MatchedBy(func(params *domain.FarmSearchParameters) bool {
+		return params.Cursor != nil && params.Cursor.ID == lastFarm.ID && params.Limit == 2
+	})).Return(&models.CursorPaginatedResponse[*domain.Farm]{
+		Items: []*domain.Farm{{ID: uuid.New()}},
+		Limit: 2,
+	}, nil).Once()
+
+	var batchSizes []int
+	err := useCase.Execute(ctx, searchParameters, 2, func(farms []*domain.Farm) error {
+		batchSizes = append(batchSizes, len(farms))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, batchSizes)
+	// the given search parameters are left untouched
+	assert.Nil(t, searchParameters.Cursor)
+	assert.Equal(t, 0, searchParameters.Limit)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportFarmsStopsOnHandlerError(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	useCase := NewExportFarmsUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	nextCursor := domain.FarmCursor{CreatedAt: time.Now().UTC(), ID: uuid.New()}.Encode()
+	mockRepo.On("ListFarmsByCursor", mock.Anything, mock.Anything).Return(&models.CursorPaginatedResponse[*domain.Farm]{
+		Items:      []*domain.Farm{{ID: uuid.New()}},
+		NextCursor: &nextCursor,
+		Limit:      1,
+	}, nil).Once()
+
+	err := useCase.Execute(ctx, &domain.FarmSearchParameters{}, 1, func(farms []*domain.Farm) error {
+		return errors.New("client gone")
+	})
+
+	assert.EqualError(t, err, "client gone")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type GetFarmUseCase interface {
+	Execute(ctx context.Context, farmId string) (*domain.Farm, error)
+}
+type GetFarm struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *GetFarm) Execute(ctx context.Context, farmId string) (*domain.Farm, error) {
+	ctx, span := startSpan(ctx, "GetFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.GetFarmByID(ctx, farmId)
+}
+
+func NewGetFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *GetFarm {
+	return &GetFarm{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type GetFarmStatsUseCase interface {
+	Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*domain.FarmStats, error)
+}
+type GetFarmStats struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *GetFarmStats) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*domain.FarmStats, error) {
+	ctx, span := startSpan(ctx, "GetFarmStats")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.GetFarmStats(ctx, searchParameters)
+}
+
+func NewGetFarmStatsUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *GetFarmStats {
+	return &GetFarmStats{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type GetLogLevelUseCase interface {
+	Execute(ctx context.Context) (string, error)
+}
+type GetLogLevel struct {
+	logLevelManager domain.LogLevelManager
+	policy          domain.AuthorizationPolicy
+}
+
+func (uc *GetLogLevel) Execute(ctx context.Context) (string, error) {
+	ctx, span := startSpan(ctx, "GetLogLevel")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionManageLogs); err != nil {
+		return "", err
+	}
+	return uc.logLevelManager.Level(), nil
+}
+
+func NewGetLogLevelUseCase(logLevelManager domain.LogLevelManager, policy domain.AuthorizationPolicy) *GetLogLevel {
+	return &GetLogLevel{
+		logLevelManager: logLevelManager,
+		policy:          policy,
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type ImportFarmsUseCase interface {
+	Execute(ctx context.Context, farms []domain.Farm, atomic bool) ([]*domain.Farm, []error, error)
+}
+
+// ImportFarms creates several farms at once. When atomic, the farms are created in a single transaction and either all of them
+// are created or none is. Otherwise each farm is created in its own transaction, the farms that failed are nil in the returned
+// farms and their error is at the same index of the returned errors.
+type ImportFarms struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+	metrics    domain.MetricsRecorder
+}
+
+func (uc *ImportFarms) Execute(ctx context.Context, farms []domain.Farm, atomic bool) ([]*domain.Farm, []error, error) {
+	ctx, span := startSpan(ctx, "ImportFarms")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionCreateFarms); err != nil {
+		return nil, nil, err
+	}
+	newFarms := make([]*domain.Farm, 0, len(farms))
+	for i := range farms {
+		farm := farms[i]
+		assignFarmIDs(&farm)
+		newFarms = append(newFarms, &farm)
+	}
+	if atomic {
+		createdFarms, err := uc.repository.CreateFarms(ctx, newFarms)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, farm := range createdFarms {
+			recordFarmCreated(uc.metrics, farm)
+		}
+		return createdFarms, make([]error, len(createdFarms)), nil
+	}
+
+	createdFarms := make([]*domain.Farm, len(newFarms))
+	errs := make([]error, len(newFarms))
+	for i, farm := range newFarms {
+		// the remaining farms can't be created once the request is cancelled
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		createdFarm, err := uc.repository.CreateFarm(ctx, farm)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		createdFarms[i] = createdFarm
+		recordFarmCreated(uc.metrics, createdFarm)
+	}
+	return createdFarms, errs, nil
+}
+
+func NewImportFarmsUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy, metrics domain.MetricsRecorder) *ImportFarms {
+	return &ImportFarms{
+		repository: repo,
+		policy:     policy,
+		metrics:    metrics,
+	}
+}
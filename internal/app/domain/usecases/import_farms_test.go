@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+func TestImportFarmsAssignsIDs(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewImportFarmsUseCase(mockRepo, allowAllPolicy{}, metrics)
+
+	ctx := context.Background()
+	farms := []domain.Farm{
+		{Name: "First Farm", LandArea: 10, UnitMeasure: domain.UnitMeasureHectares, CropProductions: []domain.CropProduction{{CropType: "RICE"}}},
+		{Name: "Second Farm", LandArea: 20, UnitMeasure: domain.UnitMeasureAcres},
+	}
+
+	mockRepo.On("CreateFarms", mock.Anything, mock.MatchedBy(func(newFarms []*domain.Farm) bool {
+		return len(newFarms) == 2 &&
+			newFarms[0].ID != uuid.Nil &&
+			newFarms[1].ID != uuid.Nil &&
+			newFarms[0].ID != newFarms[1].ID &&
+			newFarms[0].CropProductions[0].FarmID == newFarms[0].ID
+	})).Return([]*domain.Farm{&farms[0], &farms[1]}, nil)
+
+	result, errs, err := useCase.Execute(ctx, farms, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, []error{nil, nil}, errs)
+	// the given farms are left untouched
+	assert.Equal(t, uuid.Nil, farms[0].ID)
+	assert.Equal(t, 2, metrics.farmsCreated)
+	assert.Equal(t, map[string]int{"RICE": 1}, metrics.cropProductions)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportFarmsRepositoryError(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewImportFarmsUseCase(mockRepo, allowAllPolicy{}, metrics)
+
+	ctx := context.Background()
+	mockRepo.On("CreateFarms", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	result, errs, err := useCase.Execute(ctx, []domain.Farm{{Name: "Test Farm"}}, true)
+
+	assert.Nil(t, result)
+	assert.Nil(t, errs)
+	assert.EqualError(t, err, "database error")
+	assert.Equal(t, 0, metrics.farmsCreated)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportFarmsEachFarmInItsOwnTransaction(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	metrics := new(countingMetricsRecorder)
+	useCase := NewImportFarmsUseCase(mockRepo, allowAllPolicy{}, metrics)
+
+	ctx := context.Background()
+	farms := []domain.Farm{
+		{Name: "First Farm", LandArea: 10, UnitMeasure: domain.UnitMeasureHectares},
+		{Name: "Second Farm", LandArea: 20, UnitMeasure: domain.UnitMeasureAcres},
+		{Name: "Third Farm", LandArea: 30, UnitMeasure: domain.UnitMeasureHectares},
+	}
+	farmNamed := func(name string) interface{} {
+		return mock.MatchedBy(func(farm *domain.Farm) bool {
+			return farm.Name == name && farm.ID != uuid.Nil
+		})
+	}
+	mockRepo.On("CreateFarm", mock.Anything, farmNamed("First Farm")).Return(&farms[0], nil)
+	mockRepo.On("CreateFarm", mock.Anything, farmNamed("Second Farm")).Return((*domain.Farm)(nil), errors.New("database error"))
+	mockRepo.On("CreateFarm", mock.Anything, farmNamed("Third Farm")).Return(&farms[2], nil)
+
+	result, errs, err := useCase.Execute(ctx, farms, false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, "First Farm", result[0].Name)
+	assert.Nil(t, result[1])
+	assert.Equal(t, "Third Farm", result[2].Name)
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "database error")
+	assert.NoError(t, errs[2])
+	assert.Equal(t, 2, metrics.farmsCreated)
+	mockRepo.AssertNotCalled(t, "CreateFarms", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
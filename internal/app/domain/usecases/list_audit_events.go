@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+)
+
+type ListAuditEventsUseCase interface {
+	Execute(ctx context.Context, searchParameters *domain.AuditEventSearchParameters) (*models.PaginatedResponse[*domain.AuditEvent], error)
+}
+type ListAuditEvents struct {
+	repository domain.AuditEventRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *ListAuditEvents) Execute(ctx context.Context, searchParameters *domain.AuditEventSearchParameters) (*models.PaginatedResponse[*domain.AuditEvent], error) {
+	ctx, span := startSpan(ctx, "ListAuditEvents")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadAuditEvents); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListAuditEvents(ctx, searchParameters)
+}
+
+func NewListAuditEventsUseCase(repo domain.AuditEventRepository, policy domain.AuthorizationPolicy) *ListAuditEvents {
+	return &ListAuditEvents{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+type mockAuditEventRepository struct {
+	mock.Mock
+}
+
+func (m *mockAuditEventRepository) ListAuditEvents(ctx context.Context, searchParameters *domain.AuditEventSearchParameters) (*models.PaginatedResponse[*domain.AuditEvent], error) {
+	args := m.Called(ctx, searchParameters)
+	return args.Get(0).(*models.PaginatedResponse[*domain.AuditEvent]), args.Error(1)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	mockRepo := new(mockAuditEventRepository)
+	useCase := NewListAuditEventsUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	resourceID := uuid.NewString()
+	searchParameters := &domain.AuditEventSearchParameters{ResourceID: &resourceID, Page: 1, PerPage: 10}
+	expected := &models.PaginatedResponse[*domain.AuditEvent]{
+		Items:       []*domain.AuditEvent{{ID: uuid.New(), Action: domain.AuditActionCreate, ResourceID: resourceID}},
+		TotalCount:  1,
+		CurrentPage: 1,
+		PerPage:     10,
+	}
+	mockRepo.On("ListAuditEvents", mock.Anything, searchParameters).Return(expected, nil)
+
+	result, err := useCase.Execute(ctx, searchParameters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type ListCropProductionsUseCase interface {
+	Execute(ctx context.Context, farmId string) ([]domain.CropProduction, error)
+}
+type ListCropProductions struct {
+	repository domain.CropProductionRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *ListCropProductions) Execute(ctx context.Context, farmId string) ([]domain.CropProduction, error) {
+	ctx, span := startSpan(ctx, "ListCropProductions")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListCropProductions(ctx, farmId)
+}
+
+func NewListCropProductionsUseCase(repo domain.CropProductionRepository, policy domain.AuthorizationPolicy) *ListCropProductions {
+	return &ListCropProductions{
+		repository: repo,
+		policy:     policy,
+	}
+}
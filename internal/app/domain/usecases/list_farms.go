@@ -12,14 +12,21 @@ type ListFarmsUseCase interface {
 }
 type ListFarms struct {
 	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
 }
 
 func (uc *ListFarms) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.PaginatedResponse[*domain.Farm], error) {
+	ctx, span := startSpan(ctx, "ListFarms")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return nil, err
+	}
 	return uc.repository.ListFarms(ctx, searchParameters)
 }
 
-func NewListFarmsUseCase(repo domain.FarmRepository) *ListFarms {
+func NewListFarmsUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *ListFarms {
 	return &ListFarms{
 		repository: repo,
+		policy:     policy,
 	}
 }
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+)
+
+type ListFarmsByCursorUseCase interface {
+	Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.CursorPaginatedResponse[*domain.Farm], error)
+}
+type ListFarmsByCursor struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *ListFarmsByCursor) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.CursorPaginatedResponse[*domain.Farm], error) {
+	ctx, span := startSpan(ctx, "ListFarmsByCursor")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionReadFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListFarmsByCursor(ctx, searchParameters)
+}
+
+func NewListFarmsByCursorUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *ListFarmsByCursor {
+	return &ListFarmsByCursor{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -15,8 +15,72 @@ var Module = fx.Provide(
 		NewListFarmsUseCase,
 		fx.As(new(ListFarmsUseCase)),
 	),
+	fx.Annotate(
+		NewImportFarmsUseCase,
+		fx.As(new(ImportFarmsUseCase)),
+	),
+	fx.Annotate(
+		NewListFarmsByCursorUseCase,
+		fx.As(new(ListFarmsByCursorUseCase)),
+	),
+	fx.Annotate(
+		NewExportFarmsUseCase,
+		fx.As(new(ExportFarmsUseCase)),
+	),
+	fx.Annotate(
+		NewGetFarmStatsUseCase,
+		fx.As(new(GetFarmStatsUseCase)),
+	),
+	fx.Annotate(
+		NewGetFarmUseCase,
+		fx.As(new(GetFarmUseCase)),
+	),
+	fx.Annotate(
+		NewUpdateFarmUseCase,
+		fx.As(new(UpdateFarmUseCase)),
+	),
+	fx.Annotate(
+		NewPatchFarmUseCase,
+		fx.As(new(PatchFarmUseCase)),
+	),
 	fx.Annotate(
 		NewDeleteFarmUseCase,
 		fx.As(new(DeleteFarmUseCase)),
 	),
+	fx.Annotate(
+		NewRestoreFarmUseCase,
+		fx.As(new(RestoreFarmUseCase)),
+	),
+	fx.Annotate(
+		NewPurgeFarmUseCase,
+		fx.As(new(PurgeFarmUseCase)),
+	),
+	fx.Annotate(
+		NewListCropProductionsUseCase,
+		fx.As(new(ListCropProductionsUseCase)),
+	),
+	fx.Annotate(
+		NewCreateCropProductionUseCase,
+		fx.As(new(CreateCropProductionUseCase)),
+	),
+	fx.Annotate(
+		NewPatchCropProductionUseCase,
+		fx.As(new(PatchCropProductionUseCase)),
+	),
+	fx.Annotate(
+		NewDeleteCropProductionUseCase,
+		fx.As(new(DeleteCropProductionUseCase)),
+	),
+	fx.Annotate(
+		NewListAuditEventsUseCase,
+		fx.As(new(ListAuditEventsUseCase)),
+	),
+	fx.Annotate(
+		NewGetLogLevelUseCase,
+		fx.As(new(GetLogLevelUseCase)),
+	),
+	fx.Annotate(
+		NewSetLogLevelUseCase,
+		fx.As(new(SetLogLevelUseCase)),
+	),
 )
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type PatchCropProductionUseCase interface {
+	Execute(ctx context.Context, farmId string, cropProductionId string, patch domain.CropProductionPatch) (*domain.CropProduction, error)
+}
+type PatchCropProduction struct {
+	repository domain.CropProductionRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *PatchCropProduction) Execute(
+	ctx context.Context,
+	farmId string,
+	cropProductionId string,
+	patch domain.CropProductionPatch,
+) (*domain.CropProduction, error) {
+	ctx, span := startSpan(ctx, "PatchCropProduction")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionUpdateFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.PatchCropProduction(ctx, farmId, cropProductionId, patch)
+}
+
+func NewPatchCropProductionUseCase(repo domain.CropProductionRepository, policy domain.AuthorizationPolicy) *PatchCropProduction {
+	return &PatchCropProduction{
+		repository: repo,
+		policy:     policy,
+	}
+}
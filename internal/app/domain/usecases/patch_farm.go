@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type PatchFarmUseCase interface {
+	Execute(ctx context.Context, farmId string, patch domain.FarmPatch) (*domain.Farm, error)
+}
+type PatchFarm struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *PatchFarm) Execute(ctx context.Context, farmId string, patch domain.FarmPatch) (*domain.Farm, error) {
+	ctx, span := startSpan(ctx, "PatchFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionUpdateFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.PatchFarm(ctx, farmId, patch)
+}
+
+func NewPatchFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *PatchFarm {
+	return &PatchFarm{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/arthurgavazza/farm-api-challenge/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+func TestPatchFarmInTheRepository(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	useCase := NewPatchFarmUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	storedFarm := testutils.GenerateFakeFarm(nil, nil)
+	newLandArea := 321.5
+	patch := domain.FarmPatch{LandArea: &newLandArea}
+	patch.Apply(storedFarm)
+
+	// the patch is applied by the repository, in the transaction reading the stored farm
+	mockRepo.On("PatchFarm", mock.Anything, storedFarm.ID.String(), patch).Return(storedFarm, nil)
+
+	result, err := useCase.Execute(ctx, storedFarm.ID.String(), patch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, newLandArea, result.LandArea)
+	mockRepo.AssertNotCalled(t, "GetFarmByID", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "UpdateFarm", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPatchFarmNotFound(t *testing.T) {
+	mockRepo := new(mockFarmRepository)
+	useCase := NewPatchFarmUseCase(mockRepo, allowAllPolicy{})
+
+	ctx := context.Background()
+	notFoundErr := &shared.NotFoundError{Resource: "Farm", ID: "missing"}
+	patch := domain.FarmPatch{Name: testutils.PointerTo("New Name")}
+	mockRepo.On("PatchFarm", mock.Anything, "missing", patch).Return((*domain.Farm)(nil), notFoundErr)
+
+	result, err := useCase.Execute(ctx, "missing", patch)
+
+	assert.Nil(t, result)
+	assert.Equal(t, notFoundErr, err)
+	mockRepo.AssertExpectations(t)
+}
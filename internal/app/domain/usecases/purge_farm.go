@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type PurgeFarmUseCase interface {
+	Execute(ctx context.Context, farmId string) error
+}
+
+// PurgeFarm permanently removes a farm and its crop productions, unlike DeleteFarm it can't be undone
+type PurgeFarm struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *PurgeFarm) Execute(ctx context.Context, farmId string) error {
+	ctx, span := startSpan(ctx, "PurgeFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionPurgeFarms); err != nil {
+		return err
+	}
+	return uc.repository.PurgeFarm(ctx, farmId)
+}
+
+func NewPurgeFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *PurgeFarm {
+	return &PurgeFarm{
+		repository: repo,
+		policy:     policy,
+	}
+}
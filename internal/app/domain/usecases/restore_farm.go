@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type RestoreFarmUseCase interface {
+	Execute(ctx context.Context, farmId string) (*domain.Farm, error)
+}
+type RestoreFarm struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+func (uc *RestoreFarm) Execute(ctx context.Context, farmId string) (*domain.Farm, error) {
+	ctx, span := startSpan(ctx, "RestoreFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionRestoreFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.RestoreFarm(ctx, farmId)
+}
+
+func NewRestoreFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *RestoreFarm {
+	return &RestoreFarm{
+		repository: repo,
+		policy:     policy,
+	}
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type SetLogLevelUseCase interface {
+	Execute(ctx context.Context, level string) error
+}
+type SetLogLevel struct {
+	logLevelManager domain.LogLevelManager
+	policy          domain.AuthorizationPolicy
+}
+
+// Execute changes the level of the logs until the server restarts, invalid levels return domain.ErrInvalidLogLevel
+func (uc *SetLogLevel) Execute(ctx context.Context, level string) error {
+	ctx, span := startSpan(ctx, "SetLogLevel")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionManageLogs); err != nil {
+		return err
+	}
+	return uc.logLevelManager.SetLevel(level)
+}
+
+func NewSetLogLevelUseCase(logLevelManager domain.LogLevelManager, policy domain.AuthorizationPolicy) *SetLogLevel {
+	return &SetLogLevel{
+		logLevelManager: logLevelManager,
+		policy:          policy,
+	}
+}
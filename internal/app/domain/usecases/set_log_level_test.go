@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+)
+
+type mockLogLevelManager struct {
+	mock.Mock
+}
+
+func (m *mockLogLevelManager) Level() string {
+	return m.Called().String(0)
+}
+
+func (m *mockLogLevelManager) SetLevel(level string) error {
+	return m.Called(level).Error(0)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	manager := new(mockLogLevelManager)
+	manager.On("SetLevel", "debug").Return(nil)
+	manager.On("SetLevel", "verbose").Return(domain.ErrInvalidLogLevel)
+	manager.On("Level").Return("debug")
+	ctx := context.Background()
+
+	assert.NoError(t, NewSetLogLevelUseCase(manager, allowAllPolicy{}).Execute(ctx, "debug"))
+	assert.Equal(t, domain.ErrInvalidLogLevel, NewSetLogLevelUseCase(manager, allowAllPolicy{}).Execute(ctx, "verbose"))
+	level, err := NewGetLogLevelUseCase(manager, allowAllPolicy{}).Execute(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", level)
+	manager.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer delegates to the global tracer provider, which is set by the tracing module when the application starts
+var tracer = otel.Tracer("github.com/arthurgavazza/farm-api-challenge/internal/app/domain/usecases")
+
+// startSpan starts the span of a use case execution as a child of the span of ctx, usually the request span
+func startSpan(ctx context.Context, useCase string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, useCase+".Execute")
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tj/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUseCaseSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tracerProvider)
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "DELETE /farms/:id")
+
+	mockRepo := new(mockFarmRepository)
+	// the repository gets the context of the use case span
+	mockRepo.On("DeleteFarm", mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).SpanID() != parent.SpanContext().SpanID()
+	}), "farm-1").Return(nil)
+	err := NewDeleteFarmUseCase(mockRepo, allowAllPolicy{}, new(countingMetricsRecorder)).Execute(ctx, "farm-1")
+	parent.End()
+
+	assert.NoError(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "DeleteFarm.Execute", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	mockRepo.AssertExpectations(t)
+}
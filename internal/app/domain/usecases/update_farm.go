@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+)
+
+type UpdateFarmUseCase interface {
+	Execute(ctx context.Context, farm domain.Farm) (*domain.Farm, error)
+}
+type UpdateFarm struct {
+	repository domain.FarmRepository
+	policy     domain.AuthorizationPolicy
+}
+
+// Execute replaces the farm and its crop productions, crop productions without an ID are added to the farm
+// while the ones that are left out are removed.
+func (uc *UpdateFarm) Execute(ctx context.Context, farm domain.Farm) (*domain.Farm, error) {
+	ctx, span := startSpan(ctx, "UpdateFarm")
+	defer span.End()
+	if err := uc.policy.Authorize(ctx, domain.PermissionUpdateFarms); err != nil {
+		return nil, err
+	}
+	return uc.repository.UpdateFarm(ctx, &farm)
+}
+
+func NewUpdateFarmUseCase(repo domain.FarmRepository, policy domain.AuthorizationPolicy) *UpdateFarm {
+	return &UpdateFarm{
+		repository: repo,
+		policy:     policy,
+	}
+}
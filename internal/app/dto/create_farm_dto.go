@@ -13,7 +13,7 @@ type CropProductionDTO struct {
 type CreateFarmDTO struct {
 	Name            string              `json:"name" validate:"required"`
 	LandArea        float64             `json:"land_area" validate:"required,gt=0"`
-	UnitMeasure     string              `json:"unit_measure" validate:"required"`
+	UnitMeasure     string              `json:"unit_measure" validate:"required,unit_measure"`
 	Address         string              `json:"address" validate:"required"`
 	CropProductions []CropProductionDTO `json:"crop_productions" validate:"dive"`
 }
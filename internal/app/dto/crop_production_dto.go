@@ -0,0 +1,19 @@
+package dto
+
+import (
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/validation"
+)
+
+func (dto *CropProductionDTO) Validate() []shared.ErrorResponse {
+	return shared.ValidateStruct(dto)
+}
+
+type PatchCropProductionDTO struct {
+	CropType    *string `json:"crop_type" validate:"omitempty,oneof=RICE CORN COFFEE SOYBEANS"`
+	IsIrrigated *bool   `json:"is_irrigated"`
+	IsInsured   *bool   `json:"is_insured"`
+}
+
+func (dto *PatchCropProductionDTO) Validate() []shared.ErrorResponse {
+	return shared.ValidateStruct(dto)
+}
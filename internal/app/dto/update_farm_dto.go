@@ -0,0 +1,36 @@
+package dto
+
+import (
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/validation"
+)
+
+type UpdateCropProductionDTO struct {
+	ID          string `json:"id,omitempty" validate:"omitempty,uuid"`
+	CropType    string `json:"crop_type" validate:"required,oneof=RICE CORN COFFEE SOYBEANS"`
+	IsIrrigated bool   `json:"is_irrigated"`
+	IsInsured   bool   `json:"is_insured"`
+}
+
+type UpdateFarmDTO struct {
+	Name            string                    `json:"name" validate:"required"`
+	LandArea        float64                   `json:"land_area" validate:"required,gt=0"`
+	UnitMeasure     string                    `json:"unit_measure" validate:"required,unit_measure"`
+	Address         string                    `json:"address" validate:"required"`
+	CropProductions []UpdateCropProductionDTO `json:"crop_productions" validate:"dive"`
+}
+
+func (dto *UpdateFarmDTO) Validate() []shared.ErrorResponse {
+	return shared.ValidateStruct(dto)
+}
+
+type PatchFarmDTO struct {
+	Name            *string                    `json:"name" validate:"omitempty,min=1"`
+	LandArea        *float64                   `json:"land_area" validate:"omitempty,gt=0"`
+	UnitMeasure     *string                    `json:"unit_measure" validate:"omitempty,unit_measure"`
+	Address         *string                    `json:"address" validate:"omitempty,min=1"`
+	CropProductions *[]UpdateCropProductionDTO `json:"crop_productions" validate:"omitempty,dive"`
+}
+
+func (dto *PatchFarmDTO) Validate() []shared.ErrorResponse {
+	return shared.ValidateStruct(dto)
+}
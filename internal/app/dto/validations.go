@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/validation"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// unit_measure accepts any spelling understood by domain.ParseUnitMeasure
+	shared.RegisterValidation("unit_measure", func(fl validator.FieldLevel) bool {
+		_, err := domain.ParseUnitMeasure(fl.Field().String())
+		return err == nil
+	})
+}
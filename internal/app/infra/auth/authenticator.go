@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator identifies the callers from their API keys or JWT bearer tokens
+type Authenticator struct {
+	apiKeys      domain.APIKeyRepository
+	jwtSecret    []byte
+	jwtPublicKey *rsa.PublicKey
+	jwtParser    *jwt.Parser
+}
+
+// AuthenticateAPIKey returns the principal of a known and not revoked API key
+func (a *Authenticator) AuthenticateAPIKey(ctx context.Context, key string) (*domain.Principal, error) {
+	if key == "" {
+		return nil, ErrMissingCredentials
+	}
+	apiKey, err := a.apiKeys.GetAPIKeyByHash(ctx, domain.HashAPIKey(key))
+	var notFoundError *shared.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.IsRevoked() {
+		return nil, ErrInvalidCredentials
+	}
+	return &domain.Principal{
+		ID:             apiKey.ID.String(),
+		Name:           apiKey.Name,
+		Type:           domain.PrincipalTypeAPIKey,
+		Roles:          apiKey.Roles,
+		OrganizationID: apiKey.OrganizationID,
+	}, nil
+}
+
+// AuthenticateBearerToken verifies a HS256 or RS256 signed JWT and returns the principal of its subject, member of the org_id claim organization.
+// Tokens must expire, and must match the issuer and audience when they are configured
+func (a *Authenticator) AuthenticateBearerToken(token string) (*domain.Principal, error) {
+	if token == "" {
+		return nil, ErrMissingCredentials
+	}
+	claims := jwt.MapClaims{}
+	if _, err := a.jwtParser.ParseWithClaims(token, claims, a.verificationKey); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCredentials, err.Error())
+	}
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+	organizationID := uuid.Nil
+	if organization, ok := claims["org_id"].(string); ok && organization != "" {
+		if organizationID, err = uuid.Parse(organization); err != nil {
+			return nil, fmt.Errorf("%w: invalid org_id claim", ErrInvalidCredentials)
+		}
+	}
+	name, _ := claims["name"].(string)
+	return &domain.Principal{
+		ID:             subject,
+		Name:           name,
+		Type:           domain.PrincipalTypeUser,
+		Roles:          rolesClaim(claims),
+		OrganizationID: organizationID,
+	}, nil
+}
+
+// rolesClaim reads the roles claim, either a list of roles or a space separated string of roles
+func rolesClaim(claims jwt.MapClaims) []domain.Role {
+	var roles []domain.Role
+	switch claim := claims["roles"].(type) {
+	case string:
+		for _, role := range strings.Fields(claim) {
+			roles = append(roles, domain.Role(role))
+		}
+	case []interface{}:
+		for _, role := range claim {
+			if role, ok := role.(string); ok && role != "" {
+				roles = append(roles, domain.Role(role))
+			}
+		}
+	}
+	return roles
+}
+
+// verificationKey picks the configured key matching the token signing method
+func (a *Authenticator) verificationKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method {
+	case jwt.SigningMethodHS256:
+		if a.jwtSecret != nil {
+			return a.jwtSecret, nil
+		}
+	case jwt.SigningMethodRS256:
+		if a.jwtPublicKey != nil {
+			return a.jwtPublicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("signing method %s is not accepted", token.Method.Alg())
+}
+
+func NewAuthenticator(config *config.Config, apiKeys domain.APIKeyRepository) (*Authenticator, error) {
+	authenticator := &Authenticator{
+		apiKeys: apiKeys,
+	}
+	if config.Auth.JWTSecret != "" {
+		authenticator.jwtSecret = []byte(config.Auth.JWTSecret)
+	}
+	if config.Auth.JWTPublicKeyFile != "" {
+		pem, err := os.ReadFile(config.Auth.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading the JWT public key: %w", err)
+		}
+		authenticator.jwtPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the JWT public key: %w", err)
+		}
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if config.Auth.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(config.Auth.JWTIssuer))
+	}
+	if config.Auth.JWTAudience != "" {
+		options = append(options, jwt.WithAudience(config.Auth.JWTAudience))
+	}
+	authenticator.jwtParser = jwt.NewParser(options...)
+	return authenticator, nil
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *mockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+const testJWTSecret = "test-secret"
+
+func newTestAuthenticator(t *testing.T, apiKeys domain.APIKeyRepository, publicKey *rsa.PublicKey) *Authenticator {
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = testJWTSecret
+	cfg.Auth.JWTIssuer = "farm-api-tests"
+	if publicKey != nil {
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		require.NoError(t, err)
+		keyFile := filepath.Join(t.TempDir(), "jwt.pub")
+		require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+		cfg.Auth.JWTPublicKeyFile = keyFile
+	}
+	authenticator, err := NewAuthenticator(cfg, apiKeys)
+	require.NoError(t, err)
+	return authenticator
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":  "user-1",
+		"name": "Jane",
+		"iss":  "farm-api-tests",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestAuthenticateBearerToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	authenticator := newTestAuthenticator(t, nil, &privateKey.PublicKey)
+
+	expiredClaims := validClaims()
+	expiredClaims["exp"] = time.Now().Add(-time.Minute).Unix()
+	noExpirationClaims := validClaims()
+	delete(noExpirationClaims, "exp")
+	otherIssuerClaims := validClaims()
+	otherIssuerClaims["iss"] = "someone-else"
+	noSubjectClaims := validClaims()
+	delete(noSubjectClaims, "sub")
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{name: "HS256 token", token: signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), validClaims())},
+		{name: "RS256 token", token: signToken(t, jwt.SigningMethodRS256, privateKey, validClaims())},
+		{name: "Missing token", token: "", expectedError: ErrMissingCredentials},
+		{name: "Malformed token", token: "not.a.token", expectedError: ErrInvalidCredentials},
+		{name: "Wrong secret", token: signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), validClaims()), expectedError: ErrInvalidCredentials},
+		{name: "Wrong RSA key", token: signToken(t, jwt.SigningMethodRS256, otherPrivateKey, validClaims()), expectedError: ErrInvalidCredentials},
+		{name: "Unaccepted signing method", token: signToken(t, jwt.SigningMethodHS512, []byte(testJWTSecret), validClaims()), expectedError: ErrInvalidCredentials},
+		{name: "Expired token", token: signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), expiredClaims), expectedError: ErrInvalidCredentials},
+		{name: "Token without expiration", token: signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), noExpirationClaims), expectedError: ErrInvalidCredentials},
+		{name: "Token from another issuer", token: signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), otherIssuerClaims), expectedError: ErrInvalidCredentials},
+		{name: "Token without subject", token: signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), noSubjectClaims), expectedError: ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticator.AuthenticateBearerToken(tt.token)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &domain.Principal{ID: "user-1", Name: "Jane", Type: domain.PrincipalTypeUser}, principal)
+		})
+	}
+}
+
+func TestAuthenticateBearerTokenRoles(t *testing.T) {
+	authenticator := newTestAuthenticator(t, nil, nil)
+	tests := []struct {
+		name          string
+		roles         interface{}
+		expectedRoles []domain.Role
+	}{
+		{name: "List of roles", roles: []string{"viewer", "editor"}, expectedRoles: []domain.Role{domain.RoleViewer, domain.RoleEditor}},
+		{name: "Space separated roles", roles: "viewer admin", expectedRoles: []domain.Role{domain.RoleViewer, domain.RoleAdmin}},
+		{name: "Invalid roles claim", roles: 42, expectedRoles: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			claims["roles"] = tt.roles
+			principal, err := authenticator.AuthenticateBearerToken(signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRoles, principal.Roles)
+		})
+	}
+}
+
+func TestAuthenticateBearerTokenOrganization(t *testing.T) {
+	authenticator := newTestAuthenticator(t, nil, nil)
+	organizationID := uuid.New()
+
+	claims := validClaims()
+	claims["org_id"] = organizationID.String()
+	principal, err := authenticator.AuthenticateBearerToken(signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+	require.NoError(t, err)
+	assert.Equal(t, organizationID, principal.OrganizationID)
+
+	claims["org_id"] = "not-an-organization"
+	_, err = authenticator.AuthenticateBearerToken(signToken(t, jwt.SigningMethodHS256, []byte(testJWTSecret), claims))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestAuthenticateBearerTokenWithoutRSAKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	authenticator := newTestAuthenticator(t, nil, nil)
+
+	_, err = authenticator.AuthenticateBearerToken(signToken(t, jwt.SigningMethodRS256, privateKey, validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now()
+	organizationID := uuid.New()
+	activeKey := &domain.APIKey{ID: uuid.New(), OrganizationID: organizationID, Name: "finance", KeyHash: domain.HashAPIKey("active-key"), Roles: []domain.Role{domain.RoleEditor}}
+	revokedKey := &domain.APIKey{ID: uuid.New(), Name: "legacy", KeyHash: domain.HashAPIKey("revoked-key"), RevokedAt: &revokedAt}
+
+	repository := new(mockAPIKeyRepository)
+	repository.On("GetAPIKeyByHash", ctx, domain.HashAPIKey("active-key")).Return(activeKey, nil)
+	repository.On("GetAPIKeyByHash", ctx, domain.HashAPIKey("revoked-key")).Return(revokedKey, nil)
+	repository.On("GetAPIKeyByHash", ctx, domain.HashAPIKey("unknown-key")).Return(nil, &shared.NotFoundError{Resource: "API key"})
+	repository.On("GetAPIKeyByHash", ctx, domain.HashAPIKey("failing-key")).Return(nil, errors.New("database error"))
+	authenticator := newTestAuthenticator(t, repository, nil)
+
+	principal, err := authenticator.AuthenticateAPIKey(ctx, "active-key")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Principal{ID: activeKey.ID.String(), Name: "finance", Type: domain.PrincipalTypeAPIKey, Roles: []domain.Role{domain.RoleEditor}, OrganizationID: organizationID}, principal)
+
+	_, err = authenticator.AuthenticateAPIKey(ctx, "revoked-key")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authenticator.AuthenticateAPIKey(ctx, "unknown-key")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authenticator.AuthenticateAPIKey(ctx, "failing-key")
+	assert.EqualError(t, err, "database error")
+
+	_, err = authenticator.AuthenticateAPIKey(ctx, "")
+	assert.ErrorIs(t, err, ErrMissingCredentials)
+	repository.AssertExpectations(t)
+}
+
+func TestNewAuthenticatorInvalidPublicKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "jwt.pub")
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+	cfg := &config.Config{}
+	cfg.Auth.JWTPublicKeyFile = keyFile
+
+	_, err := NewAuthenticator(cfg, nil)
+	assert.Error(t, err)
+}
+
+func TestNewAuthorizationPolicy(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1", Roles: []domain.Role{"auditor"}})
+
+	policy, err := NewAuthorizationPolicy(&config.Config{})
+	require.NoError(t, err)
+	assert.Error(t, policy.Authorize(ctx, domain.PermissionReadFarms))
+
+	cfg := &config.Config{}
+	cfg.Auth.RolePermissions = "auditor=farms:read,farms:restore"
+	policy, err = NewAuthorizationPolicy(cfg)
+	require.NoError(t, err)
+	assert.NoError(t, policy.Authorize(ctx, domain.PermissionRestoreFarms))
+	assert.Error(t, policy.Authorize(ctx, domain.PermissionPurgeFarms))
+
+	cfg.Auth.RolePermissions = "auditor=farms:everything"
+	_, err = NewAuthorizationPolicy(cfg)
+	assert.ErrorIs(t, err, domain.ErrInvalidRolePermissions)
+}
@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Provide(
+	NewAuthenticator,
+	fx.Annotate(
+		NewAuthorizationPolicy,
+		fx.As(new(domain.AuthorizationPolicy)),
+	),
+)
@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+)
+
+// NewAuthorizationPolicy builds the role policy from the configured role permissions, the default roles are used when none are configured
+func NewAuthorizationPolicy(config *config.Config) (*domain.RolePolicy, error) {
+	if config.Auth.RolePermissions == "" {
+		return domain.NewRolePolicy(domain.DefaultRolePermissions()), nil
+	}
+	rolePermissions, err := domain.ParseRolePermissions(config.Auth.RolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewRolePolicy(rolePermissions), nil
+}
@@ -1,55 +1,192 @@
 package config
 
 import (
-	"fmt"
 	"os"
+	"time"
+
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 )
 
-func GetEnvOrDie(key string) string {
-	value := os.Getenv(key)
+const (
+	// MigrationModeApply applies the pending database migrations when the server starts
+	MigrationModeApply = "apply"
+	// MigrationModeVerify refuses to start the server while database migrations are pending
+	MigrationModeVerify = "verify"
+)
 
-	if value == "" {
-		err := fmt.Errorf("missing environment variable %s", key)
-		panic(err)
-	}
+// ConfigFileEnv is the environment variable holding the path of the optional YAML or TOML configuration file
+const ConfigFileEnv = "CONFIG_FILE"
 
-	return value
+// The fields are read from the configuration file keys of their yaml and toml tags, then overridden by the environment
+// variable of their env tag. The fields tagged secret are redacted when the configuration is printed.
+type Config struct {
+	Database DatabaseConfig `yaml:"database" toml:"database"`
+	Server   ServerConfig   `yaml:"server" toml:"server"`
+	Auth     AuthConfig     `yaml:"auth" toml:"auth"`
+	Log      LogConfig      `yaml:"log" toml:"log"`
+	// AccessLog sets what the request logging middleware writes about each request
+	AccessLog AccessLogConfig `yaml:"access_log" toml:"access_log"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
 }
 
-type Config struct {
-	Database struct {
-		Host     string
-		Port     string
-		User     string
-		Password string
-		Name     string
-	}
+// DatabaseConfig.MigrationMode is either MigrationModeApply or MigrationModeVerify
+type DatabaseConfig struct {
+	Host     string `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" toml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" toml:"user" env:"DB_USER"`
+	Password string `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" toml:"name" env:"DB_NAME"`
+	// SSLMode is one of the PostgreSQL sslmode values, such as disable or verify-full
+	SSLMode        string        `yaml:"ssl_mode" toml:"ssl_mode" env:"DB_SSL_MODE"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout" toml:"connect_timeout" env:"DB_CONNECT_TIMEOUT"`
+	MigrationMode  string        `yaml:"migration_mode" toml:"migration_mode" env:"DB_MIGRATION_MODE"`
+	// DefaultOrganizationID is the organization the migrations assign the farms stored without an organization to,
+	// it is only required to migrate a database holding such farms
+	DefaultOrganizationID string `yaml:"default_organization_id" toml:"default_organization_id" env:"DB_DEFAULT_ORGANIZATION_ID"`
+	// MaxOpenConns and MaxIdleConns bound the connection pool, 0 means no limit of open connections
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+}
+
+// ServerConfig timeouts of 0 mean no timeout. The metrics are served on MetricsPort, which is meant to be reachable
+// by the Prometheus server only. On shutdown the readiness check fails for ShutdownDrainDelay while the requests are
+// still served, so the load balancers stop routing to the server before it stops accepting connections
+type ServerConfig struct {
+	Port               int           `yaml:"port" toml:"port" env:"SERVER_PORT"`
+	MetricsPort        int           `yaml:"metrics_port" toml:"metrics_port" env:"SERVER_METRICS_PORT"`
+	ReadTimeout        time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout       time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout        time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" toml:"shutdown_drain_delay" env:"SERVER_SHUTDOWN_DRAIN_DELAY"`
+}
+
+// AuthConfig holds the keys used to verify the JWT bearer tokens, at least one of them must be set to accept tokens,
+// and the permissions of the roles
+type AuthConfig struct {
+	JWTSecret        string `yaml:"jwt_secret" toml:"jwt_secret" env:"AUTH_JWT_SECRET" secret:"true"`
+	JWTPublicKeyFile string `yaml:"jwt_public_key_file" toml:"jwt_public_key_file" env:"AUTH_JWT_PUBLIC_KEY_FILE"`
+	JWTIssuer        string `yaml:"jwt_issuer" toml:"jwt_issuer" env:"AUTH_JWT_ISSUER"`
+	JWTAudience      string `yaml:"jwt_audience" toml:"jwt_audience" env:"AUTH_JWT_AUDIENCE"`
+	RolePermissions  string `yaml:"role_permissions" toml:"role_permissions" env:"AUTH_ROLE_PERMISSIONS"`
+}
+
+const (
+	LogFormatJSON    = logger.FormatJSON
+	LogFormatConsole = logger.FormatConsole
+
+	LogOutputStdout = logger.OutputStdout
+	LogOutputStderr = logger.OutputStderr
+	// LogOutputFile writes the logs to LogConfig.File, rotated once it reaches FileMaxSizeMB
+	LogOutputFile = logger.OutputFile
+)
 
-	Server struct {
-		Port string
+// LogConfig.Level is one of debug, info, warn or error, it can be changed at runtime through the admin endpoint.
+// The sampling keeps the first SamplingInitial entries with the same level and message every second, then one in
+// SamplingThereafter, a SamplingInitial of 0 disables the sampling. The sampling is disabled by default since it would also
+// drop the access log entries, which all share the same message.
+type LogConfig struct {
+	Level              string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	Format             string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+	Output             string `yaml:"output" toml:"output" env:"LOG_OUTPUT"`
+	File               string `yaml:"file" toml:"file" env:"LOG_FILE"`
+	FileMaxSizeMB      int    `yaml:"file_max_size_mb" toml:"file_max_size_mb" env:"LOG_FILE_MAX_SIZE_MB"`
+	FileMaxBackups     int    `yaml:"file_max_backups" toml:"file_max_backups" env:"LOG_FILE_MAX_BACKUPS"`
+	FileMaxAgeDays     int    `yaml:"file_max_age_days" toml:"file_max_age_days" env:"LOG_FILE_MAX_AGE_DAYS"`
+	FileCompress       bool   `yaml:"file_compress" toml:"file_compress" env:"LOG_FILE_COMPRESS"`
+	SamplingInitial    int    `yaml:"sampling_initial" toml:"sampling_initial" env:"LOG_SAMPLING_INITIAL"`
+	SamplingThereafter int    `yaml:"sampling_thereafter" toml:"sampling_thereafter" env:"LOG_SAMPLING_THEREAFTER"`
+}
+
+// LoggerOptions returns the options of the application logger
+func (c LogConfig) LoggerOptions() logger.Options {
+	return logger.Options{
+		Level:              c.Level,
+		Format:             c.Format,
+		Output:             c.Output,
+		File:               c.File,
+		FileMaxSizeMB:      c.FileMaxSizeMB,
+		FileMaxBackups:     c.FileMaxBackups,
+		FileMaxAgeDays:     c.FileMaxAgeDays,
+		FileCompress:       c.FileCompress,
+		SamplingInitial:    c.SamplingInitial,
+		SamplingThereafter: c.SamplingThereafter,
 	}
 }
 
-func NewConfig() *Config {
+// AccessLogConfig lists the headers and JSON body fields whose values are redacted, case insensitively, and the paths
+// that are not logged, matched exactly or by prefix when they end with "*". The logged bodies are cut at MaxBodySize bytes,
+// 0 disables the bodies logging. The environment variables hold comma separated lists.
+type AccessLogConfig struct {
+	RedactedHeaders []string `yaml:"redacted_headers" toml:"redacted_headers" env:"ACCESS_LOG_REDACTED_HEADERS"`
+	RedactedFields  []string `yaml:"redacted_fields" toml:"redacted_fields" env:"ACCESS_LOG_REDACTED_FIELDS"`
+	MaxBodySize     int      `yaml:"max_body_size" toml:"max_body_size" env:"ACCESS_LOG_MAX_BODY_SIZE"`
+	SkipPaths       []string `yaml:"skip_paths" toml:"skip_paths" env:"ACCESS_LOG_SKIP_PATHS"`
+}
+
+const (
+	// TracingExporterOTLP sends the spans to TracingConfig.OTLPEndpoint over OTLP/HTTP
+	TracingExporterOTLP = "otlp"
+	// TracingExporterStdout writes the spans to the standard output, meant for local debugging
+	TracingExporterStdout = "stdout"
+	// TracingExporterNone doesn't export the spans, their trace IDs are still written to the logs
+	TracingExporterNone = "none"
+)
+
+// TracingConfig.OTLPEndpoint is the URL of the OTLP/HTTP collector, such as http://localhost:4318, it is required by
+// the otlp exporter. The scheme sets whether the spans are sent over TLS.
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	ServiceName  string `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// Default returns the configuration used for the settings missing from the file and the environment
+func Default() *Config {
 	return &Config{
-		Database: struct {
-			Host     string
-			Port     string
-			User     string
-			Password string
-			Name     string
-		}{
-			Host:     GetEnvOrDie("DB_HOST"),
-			Port:     GetEnvOrDie("DB_PORT"),
-			User:     GetEnvOrDie("DB_USER"),
-			Password: GetEnvOrDie("DB_PASSWORD"),
-			Name:     GetEnvOrDie("DB_NAME"),
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			ConnectTimeout:  5 * time.Second,
+			MigrationMode:   MigrationModeApply,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
 		},
-
-		Server: struct {
-			Port string
-		}{
-			Port: GetEnvOrDie("SERVER_PORT"),
+		Server: ServerConfig{
+			Port:         8080,
+			MetricsPort:  9091,
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 30 * time.Second,
+			IdleTimeout:  2 * time.Minute,
+			// below the 15 seconds fx gives the stop hooks, leaving time to finish the requests in flight
+			ShutdownDrainDelay: 5 * time.Second,
+		},
+		Log: LogConfig{
+			Level:              "info",
+			Format:             LogFormatJSON,
+			Output:             LogOutputStderr,
+			FileMaxSizeMB:      100,
+			FileMaxBackups:     5,
+			FileMaxAgeDays:     30,
+			SamplingInitial:    0,
+			SamplingThereafter: 100,
+		},
+		AccessLog: AccessLogConfig{
+			RedactedHeaders: []string{"Authorization", "Cookie", "Set-Cookie", "X-API-Key"},
+			RedactedFields:  []string{"password", "token", "secret", "api_key"},
+			MaxBodySize:     4096,
+			SkipPaths:       []string{"/healthcheck", "/healthcheck/*", "/swagger/*"},
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			ServiceName: "farm-api",
 		},
 	}
 }
+
+// NewConfig loads the configuration from the file set in CONFIG_FILE, if any, and the environment
+func NewConfig() (*Config, error) {
+	return Load(os.Getenv(ConfigFileEnv))
+}
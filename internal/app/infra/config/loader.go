@@ -0,0 +1,230 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError lists every problem found in the configuration, so they can all be fixed at once
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load returns the default configuration overridden by the file at path, when path is not empty,
+// and then by the environment variables. The problems of the file, the environment and the resulting
+// configuration are returned together in a ValidationError.
+func Load(path string) (*Config, error) {
+	config := Default()
+	var problems []string
+	if path != "" {
+		fileProblems, err := readFile(path, config)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, fileProblems...)
+	}
+	problems = append(problems, applyEnv(reflect.ValueOf(config).Elem())...)
+	problems = append(problems, config.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return config, nil
+}
+
+// readFile decodes the YAML or TOML file into config, the unknown keys are returned as problems
+func readFile(path string, config *Config) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return []string{fmt.Sprintf("%s: %s", path, err)}, nil
+		}
+		return nil, nil
+	case ".toml":
+		metadata, err := toml.Decode(string(content), config)
+		if err != nil {
+			return []string{fmt.Sprintf("%s: %s", path, err)}, nil
+		}
+		var problems []string
+		for _, key := range metadata.Undecoded() {
+			problems = append(problems, fmt.Sprintf("%s: unknown key %s", path, key))
+		}
+		return problems, nil
+	default:
+		return nil, fmt.Errorf("the configuration file %s must be a .yaml, .yml or .toml file", path)
+	}
+}
+
+// applyEnv overrides the fields of value with the environment variables of their env tag, the variables
+// that can't be parsed are returned as problems
+func applyEnv(value reflect.Value) []string {
+	var problems []string
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structField := value.Type().Field(i)
+		if structField.Type.Kind() == reflect.Struct {
+			problems = append(problems, applyEnv(field)...)
+			continue
+		}
+		key := structField.Tag.Get("env")
+		raw, exists := os.LookupEnv(key)
+		if key == "" || !exists || raw == "" {
+			continue
+		}
+		switch {
+		case structField.Type == durationType:
+			duration, err := time.ParseDuration(raw)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be a duration such as 30s or 5m, got %q", key, raw))
+				continue
+			}
+			field.SetInt(int64(duration))
+		case structField.Type.Kind() == reflect.Int:
+			number, err := strconv.Atoi(raw)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be an integer, got %q", key, raw))
+				continue
+			}
+			field.SetInt(int64(number))
+		case structField.Type.Kind() == reflect.Bool:
+			value, err := strconv.ParseBool(raw)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be true or false, got %q", key, raw))
+				continue
+			}
+			field.SetBool(value)
+		case structField.Type.Kind() == reflect.String:
+			field.SetString(raw)
+		case structField.Type.Kind() == reflect.Slice && structField.Type.Elem().Kind() == reflect.String:
+			var values []string
+			for _, value := range strings.Split(raw, ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					values = append(values, value)
+				}
+			}
+			field.Set(reflect.ValueOf(values))
+		}
+	}
+	return problems
+}
+
+var (
+	sslModes  = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels = []string{"debug", "info", "warn", "error"}
+)
+
+func (c *Config) validate() []string {
+	var problems []string
+	required := func(name string, value string) {
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", name))
+		}
+	}
+	oneOf := func(name string, value string, allowed []string) {
+		for _, candidate := range allowed {
+			if value == candidate {
+				return
+			}
+		}
+		problems = append(problems, fmt.Sprintf("%s must be one of %s, got %q", name, strings.Join(allowed, ", "), value))
+	}
+	port := func(name string, value int) {
+		if value < 1 || value > 65535 {
+			problems = append(problems, fmt.Sprintf("%s must be between 1 and 65535, got %d", name, value))
+		}
+	}
+	uuidValue := func(name string, value string) {
+		if value != "" && uuid.Validate(value) != nil {
+			problems = append(problems, fmt.Sprintf("%s must be a UUID, got %q", name, value))
+		}
+	}
+	notNegative := func(name string, value int64) {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative", name))
+		}
+	}
+
+	required("database.host (DB_HOST)", c.Database.Host)
+	port("database.port (DB_PORT)", c.Database.Port)
+	required("database.user (DB_USER)", c.Database.User)
+	required("database.password (DB_PASSWORD)", c.Database.Password)
+	required("database.name (DB_NAME)", c.Database.Name)
+	oneOf("database.ssl_mode (DB_SSL_MODE)", c.Database.SSLMode, sslModes)
+	notNegative("database.connect_timeout (DB_CONNECT_TIMEOUT)", int64(c.Database.ConnectTimeout))
+	oneOf("database.migration_mode (DB_MIGRATION_MODE)", c.Database.MigrationMode, []string{MigrationModeApply, MigrationModeVerify})
+	uuidValue("database.default_organization_id (DB_DEFAULT_ORGANIZATION_ID)", c.Database.DefaultOrganizationID)
+	notNegative("database.max_open_conns (DB_MAX_OPEN_CONNS)", int64(c.Database.MaxOpenConns))
+	notNegative("database.max_idle_conns (DB_MAX_IDLE_CONNS)", int64(c.Database.MaxIdleConns))
+	notNegative("database.conn_max_lifetime (DB_CONN_MAX_LIFETIME)", int64(c.Database.ConnMaxLifetime))
+	port("server.port (SERVER_PORT)", c.Server.Port)
+	port("server.metrics_port (SERVER_METRICS_PORT)", c.Server.MetricsPort)
+	if c.Server.MetricsPort == c.Server.Port {
+		problems = append(problems, "server.metrics_port (SERVER_METRICS_PORT) must differ from server.port (SERVER_PORT)")
+	}
+	notNegative("server.read_timeout (SERVER_READ_TIMEOUT)", int64(c.Server.ReadTimeout))
+	notNegative("server.write_timeout (SERVER_WRITE_TIMEOUT)", int64(c.Server.WriteTimeout))
+	notNegative("server.idle_timeout (SERVER_IDLE_TIMEOUT)", int64(c.Server.IdleTimeout))
+	notNegative("server.shutdown_drain_delay (SERVER_SHUTDOWN_DRAIN_DELAY)", int64(c.Server.ShutdownDrainDelay))
+	oneOf("log.level (LOG_LEVEL)", c.Log.Level, logLevels)
+	oneOf("log.format (LOG_FORMAT)", c.Log.Format, []string{LogFormatJSON, LogFormatConsole})
+	oneOf("log.output (LOG_OUTPUT)", c.Log.Output, []string{LogOutputStdout, LogOutputStderr, LogOutputFile})
+	if c.Log.Output == LogOutputFile {
+		required("log.file (LOG_FILE)", c.Log.File)
+	}
+	notNegative("log.file_max_size_mb (LOG_FILE_MAX_SIZE_MB)", int64(c.Log.FileMaxSizeMB))
+	notNegative("log.file_max_backups (LOG_FILE_MAX_BACKUPS)", int64(c.Log.FileMaxBackups))
+	notNegative("log.file_max_age_days (LOG_FILE_MAX_AGE_DAYS)", int64(c.Log.FileMaxAgeDays))
+	notNegative("log.sampling_initial (LOG_SAMPLING_INITIAL)", int64(c.Log.SamplingInitial))
+	notNegative("log.sampling_thereafter (LOG_SAMPLING_THEREAFTER)", int64(c.Log.SamplingThereafter))
+	notNegative("access_log.max_body_size (ACCESS_LOG_MAX_BODY_SIZE)", int64(c.AccessLog.MaxBodySize))
+	oneOf("tracing.exporter (TRACING_EXPORTER)", c.Tracing.Exporter, []string{TracingExporterOTLP, TracingExporterStdout, TracingExporterNone})
+	if c.Tracing.Exporter == TracingExporterOTLP {
+		required("tracing.otlp_endpoint (TRACING_OTLP_ENDPOINT)", c.Tracing.OTLPEndpoint)
+	}
+	required("tracing.service_name (TRACING_SERVICE_NAME)", c.Tracing.ServiceName)
+	return problems
+}
+
+// Redacted returns a copy of the configuration with the secret fields masked, meant to be printed
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redact(reflect.ValueOf(&redacted).Elem())
+	return &redacted
+}
+
+func redact(value reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structField := value.Type().Field(i)
+		if structField.Type.Kind() == reflect.Struct {
+			redact(field)
+			continue
+		}
+		if structField.Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString("********")
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var environmentVariables = []string{
+	"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSL_MODE", "DB_CONNECT_TIMEOUT", "DB_MIGRATION_MODE",
+	"DB_DEFAULT_ORGANIZATION_ID", "DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "SERVER_PORT",
+	"SERVER_METRICS_PORT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_IDLE_TIMEOUT",
+	"SERVER_SHUTDOWN_DRAIN_DELAY", "AUTH_JWT_SECRET", "AUTH_JWT_PUBLIC_KEY_FILE", "AUTH_JWT_ISSUER", "AUTH_JWT_AUDIENCE",
+	"AUTH_ROLE_PERMISSIONS", "LOG_LEVEL", "LOG_FORMAT", "LOG_OUTPUT", "LOG_FILE", "LOG_FILE_MAX_SIZE_MB",
+	"LOG_FILE_MAX_BACKUPS", "LOG_FILE_MAX_AGE_DAYS", "LOG_FILE_COMPRESS", "LOG_SAMPLING_INITIAL",
+	"LOG_SAMPLING_THEREAFTER", "ACCESS_LOG_REDACTED_HEADERS", "ACCESS_LOG_REDACTED_FIELDS", "ACCESS_LOG_MAX_BODY_SIZE",
+	"ACCESS_LOG_SKIP_PATHS", "TRACING_EXPORTER", "TRACING_OTLP_ENDPOINT", "TRACING_SERVICE_NAME",
+}
+
+// setEnv clears the configuration environment variables of the test process, then sets the given ones
+func setEnv(t *testing.T, values map[string]string) {
+	for _, key := range environmentVariables {
+		t.Setenv(key, "")
+	}
+	for key, value := range values {
+		t.Setenv(key, value)
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFromEnvironment(t *testing.T) {
+	setEnv(t, map[string]string{
+		"DB_USER":               "postgres",
+		"DB_PASSWORD":           "postgres",
+		"DB_NAME":               "farms",
+		"DB_PORT":               "5433",
+		"DB_CONN_MAX_LIFETIME":  "1h",
+		"SERVER_PORT":           "9090",
+		"ACCESS_LOG_SKIP_PATHS": "/healthcheck, /metrics",
+	})
+
+	config, err := Load("")
+	require.NoError(t, err)
+	expected := Default()
+	expected.Database.User = "postgres"
+	expected.Database.Password = "postgres"
+	expected.Database.Name = "farms"
+	expected.Database.Port = 5433
+	expected.Database.ConnMaxLifetime = time.Hour
+	expected.Server.Port = 9090
+	expected.AccessLog.SkipPaths = []string{"/healthcheck", "/metrics"}
+	assert.Equal(t, expected, config)
+}
+
+func TestLoadFromFile(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+database:
+  host: db.internal
+  user: farms
+  password: from-file
+  name: farms
+  ssl_mode: verify-full
+  connect_timeout: 10s
+server:
+  read_timeout: 5s
+log:
+  level: debug
+access_log:
+  redacted_fields: [password, ssn]
+`,
+		"config.toml": `
+[database]
+host = "db.internal"
+user = "farms"
+password = "from-file"
+name = "farms"
+ssl_mode = "verify-full"
+connect_timeout = "10s"
+
+[server]
+read_timeout = "5s"
+
+[log]
+level = "debug"
+
+[access_log]
+redacted_fields = ["password", "ssn"]
+`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			setEnv(t, map[string]string{"DB_PASSWORD": "from-env"})
+
+			config, err := Load(writeFile(t, name, content))
+			require.NoError(t, err)
+			assert.Equal(t, "db.internal", config.Database.Host)
+			assert.Equal(t, "verify-full", config.Database.SSLMode)
+			assert.Equal(t, 10*time.Second, config.Database.ConnectTimeout)
+			assert.Equal(t, 5*time.Second, config.Server.ReadTimeout)
+			assert.Equal(t, "debug", config.Log.Level)
+			assert.Equal(t, []string{"password", "ssn"}, config.AccessLog.RedactedFields)
+			// the environment overrides the file, the missing settings keep their default
+			assert.Equal(t, "from-env", config.Database.Password)
+			assert.Equal(t, 5432, config.Database.Port)
+			assert.Equal(t, 30*time.Second, config.Server.WriteTimeout)
+		})
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	setEnv(t, map[string]string{
+		"DB_PORT":                     "abc",
+		"DB_SSL_MODE":                 "on",
+		"DB_MIGRATION_MODE":           "skip",
+		"DB_DEFAULT_ORGANIZATION_ID":  "acme",
+		"SERVER_PORT":                 "70000",
+		"SERVER_IDLE_TIMEOUT":         "-1s",
+		"SERVER_SHUTDOWN_DRAIN_DELAY": "-5s",
+		"DB_CONNECT_TIMEOUT":          "soon",
+		"LOG_LEVEL":                   "loud",
+		"LOG_OUTPUT":                  "file",
+		"LOG_FILE_COMPRESS":           "maybe",
+		"TRACING_EXPORTER":            "otlp",
+	})
+	path := writeFile(t, "config.yaml", "database:\n  usr: farms\n")
+
+	config, err := Load(path)
+	assert.Nil(t, config)
+	var validationError *ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Len(t, validationError.Problems, 16)
+	for _, expected := range []string{
+		"field usr not found",
+		`DB_PORT must be an integer, got "abc"`,
+		`DB_CONNECT_TIMEOUT must be a duration such as 30s or 5m, got "soon"`,
+		"database.user (DB_USER) is required",
+		"database.password (DB_PASSWORD) is required",
+		"database.name (DB_NAME) is required",
+		`database.ssl_mode (DB_SSL_MODE) must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"`,
+		`database.migration_mode (DB_MIGRATION_MODE) must be one of apply, verify, got "skip"`,
+		`database.default_organization_id (DB_DEFAULT_ORGANIZATION_ID) must be a UUID, got "acme"`,
+		"server.port (SERVER_PORT) must be between 1 and 65535, got 70000",
+		"server.idle_timeout (SERVER_IDLE_TIMEOUT) must not be negative",
+		"server.shutdown_drain_delay (SERVER_SHUTDOWN_DRAIN_DELAY) must not be negative",
+		`log.level (LOG_LEVEL) must be one of debug, info, warn, error, got "loud"`,
+		`LOG_FILE_COMPRESS must be true or false, got "maybe"`,
+		"log.file (LOG_FILE) is required",
+		"tracing.otlp_endpoint (TRACING_OTLP_ENDPOINT) is required",
+	} {
+		assert.Contains(t, err.Error(), expected)
+	}
+}
+
+func TestLoadMetricsPortMustDifferFromPort(t *testing.T) {
+	setEnv(t, map[string]string{
+		"DB_USER":             "farms",
+		"DB_PASSWORD":         "farms",
+		"DB_NAME":             "farms",
+		"SERVER_PORT":         "9091",
+		"SERVER_METRICS_PORT": "9091",
+	})
+
+	_, err := Load("")
+	var validationError *ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Equal(t, []string{"server.metrics_port (SERVER_METRICS_PORT) must differ from server.port (SERVER_PORT)"}, validationError.Problems)
+}
+
+func TestLoadUnknownTOMLKey(t *testing.T) {
+	setEnv(t, map[string]string{"DB_USER": "farms", "DB_PASSWORD": "farms", "DB_NAME": "farms"})
+
+	_, err := Load(writeFile(t, "config.toml", "[server]\nprot = 8080\n"))
+	var validationError *ValidationError
+	require.ErrorAs(t, err, &validationError)
+	assert.Len(t, validationError.Problems, 1)
+	assert.Contains(t, validationError.Problems[0], "unknown key server.prot")
+}
+
+func TestLoadUnsupportedFile(t *testing.T) {
+	setEnv(t, nil)
+
+	path := writeFile(t, "config.json", "{}")
+	_, err := Load(path)
+	assert.EqualError(t, err, "the configuration file "+path+" must be a .yaml, .yml or .toml file")
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRedacted(t *testing.T) {
+	config := Default()
+	config.Database.Password = "postgres"
+	config.Auth.JWTIssuer = "farm-api"
+
+	redacted := config.Redacted()
+	assert.Equal(t, "********", redacted.Database.Password)
+	// secrets that are not set stay empty, so the output shows they are missing
+	assert.Equal(t, "", redacted.Auth.JWTSecret)
+	assert.Equal(t, "farm-api", redacted.Auth.JWTIssuer)
+	assert.Equal(t, "postgres", config.Database.Password)
+}
+
+func TestDefaultLoggerOptions(t *testing.T) {
+	options := Default().Log.LoggerOptions()
+	defaults := logger.DefaultOptions()
+
+	// the configuration defaults match the defaults of the loggers created before the configuration is loaded
+	assert.Equal(t, defaults.Level, options.Level)
+	assert.Equal(t, defaults.Format, options.Format)
+	assert.Equal(t, defaults.Output, options.Output)
+	assert.Equal(t, defaults.SamplingInitial, options.SamplingInitial)
+}
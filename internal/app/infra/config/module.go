@@ -1,7 +1,15 @@
 package config
 
-import "go.uber.org/fx"
+import (
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"go.uber.org/fx"
+)
+
+func newLoggerOptions(config *Config) logger.Options {
+	return config.Log.LoggerOptions()
+}
 
 var Module = fx.Provide(
 	NewConfig,
+	newLoggerOptions,
 )
@@ -1,47 +1,114 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
-	"log"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
-	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-var (
-	db   *gorm.DB
-	once sync.Once
-)
+// RetryPolicy sets how many times the connection to the database is attempted, waiting an exponentially
+// growing delay between the attempts, e.g. while PostgreSQL is still starting in docker-compose
+type RetryPolicy struct {
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:       8,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
 
-func generateConnectionString(config *config.Config) string {
+// backoff returns the delay before the attempt following the given one, attempts start at 1
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// ConnectionString returns the PostgreSQL key/value connection string of the configured database,
+// the values are quoted so they may hold spaces, quotes and backslashes
+func ConnectionString(config *config.Config) string {
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		config.Database.Host,
-		config.Database.User,
-		config.Database.Password,
-		config.Database.Name,
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s connect_timeout=%d",
+		quoteConnectionValue(config.Database.Host),
+		quoteConnectionValue(config.Database.User),
+		quoteConnectionValue(config.Database.Password),
+		quoteConnectionValue(config.Database.Name),
 		config.Database.Port,
+		quoteConnectionValue(config.Database.SSLMode),
+		int(config.Database.ConnectTimeout.Seconds()),
 	)
 	return dsn
+}
+
+// connectionValueEscaper escapes the characters that end or escape a quoted connection string value
+var connectionValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
 
+func quoteConnectionValue(value string) string {
+	return "'" + connectionValueEscaper.Replace(value) + "'"
 }
 
-func NewPostgresDatabase(connectionString string) *gorm.DB {
-	once.Do(func() {
-		var err error
-		db, err = gorm.Open(postgres.Open(connectionString), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Error),
-		})
-		if err != nil {
-			log.Fatalln("Failed to connect to database:", err)
+// ConfigurePool applies the connection pool limits of the configuration
+func ConfigurePool(sqlDB *sql.DB, config *config.Config) {
+	sqlDB.SetMaxOpenConns(config.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(config.Database.ConnMaxLifetime)
+}
+
+// retry calls ping until it succeeds or the attempts of the policy are exhausted, onRetry is called before each wait
+func retry(ctx context.Context, policy RetryPolicy, ping func(ctx context.Context) error, onRetry func(attempt int, delay time.Duration, err error)) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = ping(ctx); err == nil {
+			return nil
+		}
+		if attempt >= policy.Attempts {
+			return fmt.Errorf("failed to connect to the database after %d attempts: %w", attempt, err)
+		}
+		delay := policy.backoff(attempt)
+		if onRetry != nil {
+			onRetry(attempt, delay, err)
 		}
-		db.AutoMigrate(&entities.Farm{}, &entities.CropProduction{})
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to the database: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
 
+// NewPostgresDatabase opens a database and waits until it accepts connections, following the retry policy.
+// onRetry, which may be nil, is called after every failed attempt.
+func NewPostgresDatabase(ctx context.Context, connectionString string, policy RetryPolicy, onRetry func(attempt int, delay time.Duration, err error)) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(connectionString), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Error),
+		// the connection is checked below, so a database that is still starting is retried instead of failing
+		DisableAutomaticPing: true,
 	})
-
-	return db
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := retry(ctx, policy, sqlDB.PingContext, onRetry); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
 }
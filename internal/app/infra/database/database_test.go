@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{Attempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+	assert.Equal(t, time.Second, policy.backoff(9))
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	unavailable := errors.New("connection refused")
+
+	tests := []struct {
+		name             string
+		failures         int
+		expectedAttempts int
+		expectedError    bool
+	}{
+		{name: "Available database", failures: 0, expectedAttempts: 1},
+		{name: "Database starting", failures: 2, expectedAttempts: 3},
+		{name: "Unavailable database", failures: 5, expectedAttempts: 3, expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			var retries []int
+			err := retry(context.Background(), policy, func(context.Context) error {
+				attempts++
+				if attempts <= tt.failures {
+					return unavailable
+				}
+				return nil
+			}, func(attempt int, _ time.Duration, _ error) {
+				retries = append(retries, attempt)
+			})
+
+			assert.Equal(t, tt.expectedAttempts, attempts)
+			if tt.expectedError {
+				assert.ErrorIs(t, err, unavailable)
+				assert.Equal(t, []int{1, 2}, retries)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryStopsWhenTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{Attempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	err := retry(ctx, policy, func(context.Context) error {
+		return errors.New("connection refused")
+	}, func(int, time.Duration, error) {
+		cancel()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConnectionStringQuotesTheValues(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.User = "farm api"
+	cfg.Database.Password = `p@ss 'word\ sslmode=disable`
+	cfg.Database.Name = "farms"
+
+	parsed, err := pgconn.ParseConfig(ConnectionString(cfg))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", parsed.Host)
+	assert.Equal(t, "farm api", parsed.User)
+	assert.Equal(t, `p@ss 'word\ sslmode=disable`, parsed.Password)
+	assert.Equal(t, "farms", parsed.Database)
+	assert.Equal(t, uint16(5432), parsed.Port)
+	assert.Equal(t, 5*time.Second, parsed.ConnectTimeout)
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKey struct {
+	ID             uuid.UUID  `gorm:"primaryKey"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;index"` // organization the key acts on behalf of
+	Name           string     `gorm:"size:255;not null"`
+	KeyHash        string     `gorm:"size:64;not null;uniqueIndex"` // hex encoded SHA-256 of the key, the key itself is never stored
+	Roles          string     `gorm:"size:255;not null;default:''"` // comma separated roles granted to the key
+	CreatedAt      time.Time  `gorm:"not null"`
+	RevokedAt      *time.Time `gorm:"index"`
+}
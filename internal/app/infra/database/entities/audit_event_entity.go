@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEvent struct {
+	ID             uuid.UUID `gorm:"primaryKey"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ActorID        string    `gorm:"size:255;not null;index"`
+	ActorType      string    `gorm:"size:50;not null"`
+	Action         string    `gorm:"size:50;not null"`
+	ResourceType   string    `gorm:"size:50;not null;index:idx_audit_events_resource"`
+	ResourceID     string    `gorm:"size:255;not null;index:idx_audit_events_resource"`
+	RequestID      string    `gorm:"size:255;not null;default:''"`
+	Before         []byte    `gorm:"type:jsonb"` // snapshot of the resource before the mutation, NULL for creations
+	After          []byte    `gorm:"type:jsonb"` // snapshot of the resource after the mutation, NULL for purges
+	CreatedAt      time.Time `gorm:"not null;index"`
+}
@@ -8,13 +8,15 @@ import (
 )
 
 type Farm struct {
-	ID              uuid.UUID        `gorm:"primaryKey"`
-	Name            string           `gorm:"size:255;not null"`
-	LandArea        float64          `gorm:"not null"`
-	UnitMeasure     string           `gorm:"size:50;not null"`
-	Address         string           `gorm:"size:255;not null"`
-	CropProductions []CropProduction `gorm:"foreignKey:FarmID;constraint:OnDelete:CASCADE;"`
-	CreatedAt       time.Time        `gorm:"not null"`
-	UpdatedAt       time.Time        `gorm:"not null"`
-	DeletedAt       gorm.DeletedAt   `gorm:"index"`
+	ID               uuid.UUID        `gorm:"primaryKey"`
+	OrganizationID   uuid.UUID        `gorm:"type:uuid;not null;index"` // organization owning the farm, every farm query is scoped to the caller organization
+	Name             string           `gorm:"size:255;not null"`
+	LandArea         float64          `gorm:"not null"`
+	LandAreaHectares float64          `gorm:"not null;default:0;index"` // LandArea converted to hectares, used by the land area filters
+	UnitMeasure      string           `gorm:"size:50;not null"`
+	Address          string           `gorm:"size:255;not null"`
+	CropProductions  []CropProduction `gorm:"foreignKey:FarmID;constraint:OnDelete:CASCADE;"`
+	CreatedAt        time.Time        `gorm:"not null"`
+	UpdatedAt        time.Time        `gorm:"not null"`
+	DeletedAt        gorm.DeletedAt   `gorm:"index"`
 }
@@ -1,18 +1,24 @@
 package mappers
 
 import (
+	"strings"
+	"time"
+
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
+	"gorm.io/gorm"
 )
 
 func ToGormFarm(domainFarm *domain.Farm) *entities.Farm {
 	return &entities.Farm{
-		ID:              domainFarm.ID,
-		Name:            domainFarm.Name,
-		LandArea:        domainFarm.LandArea,
-		UnitMeasure:     domainFarm.UnitMeasure,
-		Address:         domainFarm.Address,
-		CropProductions: ToGormCropProductions(domainFarm.CropProductions),
+		ID:               domainFarm.ID,
+		OrganizationID:   domainFarm.OrganizationID,
+		Name:             domainFarm.Name,
+		LandArea:         domainFarm.LandArea,
+		LandAreaHectares: domainFarm.LandAreaInHectares(),
+		UnitMeasure:      domainFarm.UnitMeasure.String(),
+		Address:          domainFarm.Address,
+		CropProductions:  ToGormCropProductions(domainFarm.CropProductions),
 	}
 }
 
@@ -33,16 +39,27 @@ func ToGormCropProductions(domainCrops []domain.CropProduction) []entities.CropP
 func ToDomainFarm(ormFarm *entities.Farm) *domain.Farm {
 	return &domain.Farm{
 		ID:              ormFarm.ID,
+		OrganizationID:  ormFarm.OrganizationID,
 		Name:            ormFarm.Name,
 		LandArea:        ormFarm.LandArea,
-		UnitMeasure:     ormFarm.UnitMeasure,
+		UnitMeasure:     domain.UnitMeasure(ormFarm.UnitMeasure),
 		Address:         ormFarm.Address,
+		CreatedAt:       ormFarm.CreatedAt,
+		UpdatedAt:       ormFarm.UpdatedAt,
+		DeletedAt:       toDomainDeletedAt(ormFarm.DeletedAt),
 		CropProductions: ToDomainCropProductions(ormFarm.CropProductions),
 	}
 }
 
+func toDomainDeletedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
 func ToDomainCropProductions(domainCrops []entities.CropProduction) []domain.CropProduction {
-	var crops []domain.CropProduction
+	crops := make([]domain.CropProduction, 0, len(domainCrops))
 	for _, crop := range domainCrops {
 		crops = append(crops, domain.CropProduction{
 			CropType:    crop.CropType,
@@ -54,3 +71,61 @@ func ToDomainCropProductions(domainCrops []entities.CropProduction) []domain.Cro
 	}
 	return crops
 }
+
+func ToDomainAPIKey(ormAPIKey *entities.APIKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:             ormAPIKey.ID,
+		OrganizationID: ormAPIKey.OrganizationID,
+		Name:           ormAPIKey.Name,
+		KeyHash:        ormAPIKey.KeyHash,
+		Roles:          toDomainRoles(ormAPIKey.Roles),
+		CreatedAt:      ormAPIKey.CreatedAt,
+		RevokedAt:      ormAPIKey.RevokedAt,
+	}
+}
+
+func toDomainRoles(roles string) []domain.Role {
+	domainRoles := []domain.Role{}
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			domainRoles = append(domainRoles, domain.Role(role))
+		}
+	}
+	return domainRoles
+}
+
+func ToGormAuditEvents(domainEvents []*domain.AuditEvent) []entities.AuditEvent {
+	events := make([]entities.AuditEvent, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		events = append(events, entities.AuditEvent{
+			ID:             event.ID,
+			OrganizationID: event.OrganizationID,
+			ActorID:        event.ActorID,
+			ActorType:      string(event.ActorType),
+			Action:         string(event.Action),
+			ResourceType:   string(event.ResourceType),
+			ResourceID:     event.ResourceID,
+			RequestID:      event.RequestID,
+			Before:         event.Before,
+			After:          event.After,
+			CreatedAt:      event.CreatedAt,
+		})
+	}
+	return events
+}
+
+func ToDomainAuditEvent(ormEvent *entities.AuditEvent) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		ID:             ormEvent.ID,
+		OrganizationID: ormEvent.OrganizationID,
+		ActorID:        ormEvent.ActorID,
+		ActorType:      domain.PrincipalType(ormEvent.ActorType),
+		Action:         domain.AuditAction(ormEvent.Action),
+		ResourceType:   domain.AuditResourceType(ormEvent.ResourceType),
+		ResourceID:     ormEvent.ResourceID,
+		RequestID:      ormEvent.RequestID,
+		Before:         ormEvent.Before,
+		After:          ormEvent.After,
+		CreatedAt:      ormEvent.CreatedAt,
+	}
+}
@@ -2,6 +2,7 @@ package mappers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
@@ -11,11 +12,12 @@ import (
 
 func TestToGormFarm(t *testing.T) {
 	domainFarm := &domain.Farm{
-		ID:          uuid.New(),
-		Name:        "Test Farm",
-		LandArea:    100.5,
-		UnitMeasure: "hectares",
-		Address:     "123 Farm Lane",
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		Name:           "Test Farm",
+		LandArea:       100.5,
+		UnitMeasure:    "hectares",
+		Address:        "123 Farm Lane",
 		CropProductions: []domain.CropProduction{
 			{
 				ID:          uuid.New(),
@@ -38,9 +40,11 @@ func TestToGormFarm(t *testing.T) {
 
 	assert.NotNil(t, result)
 	assert.Equal(t, domainFarm.ID, result.ID)
+	assert.Equal(t, domainFarm.OrganizationID, result.OrganizationID)
 	assert.Equal(t, domainFarm.Name, result.Name)
 	assert.Equal(t, domainFarm.LandArea, result.LandArea)
-	assert.Equal(t, domainFarm.UnitMeasure, result.UnitMeasure)
+	assert.Equal(t, domainFarm.UnitMeasure.String(), result.UnitMeasure)
+	assert.Equal(t, domainFarm.LandArea, result.LandAreaHectares)
 	assert.Equal(t, domainFarm.Address, result.Address)
 	assert.Len(t, result.CropProductions, len(domainFarm.CropProductions))
 
@@ -114,7 +118,7 @@ func TestToDomainFarm(t *testing.T) {
 	assert.Equal(t, gormFarm.ID, result.ID)
 	assert.Equal(t, gormFarm.Name, result.Name)
 	assert.Equal(t, gormFarm.LandArea, result.LandArea)
-	assert.Equal(t, gormFarm.UnitMeasure, result.UnitMeasure)
+	assert.Equal(t, gormFarm.UnitMeasure, result.UnitMeasure.String())
 	assert.Equal(t, gormFarm.Address, result.Address)
 	assert.Len(t, result.CropProductions, len(gormFarm.CropProductions))
 
@@ -156,3 +160,27 @@ func TestToDomainCropProductions(t *testing.T) {
 		assert.Equal(t, crop.FarmID, result[i].FarmID)
 	}
 }
+
+func TestAuditEventMappers(t *testing.T) {
+	domainEvent := &domain.AuditEvent{
+		ID:             uuid.New(),
+		OrganizationID: uuid.New(),
+		ActorID:        "user-1",
+		ActorType:      domain.PrincipalTypeUser,
+		Action:         domain.AuditActionUpdate,
+		ResourceType:   domain.AuditResourceFarm,
+		ResourceID:     uuid.NewString(),
+		RequestID:      "request-1",
+		Before:         []byte(`{"name":"Old Farm"}`),
+		After:          []byte(`{"name":"New Farm"}`),
+		CreatedAt:      time.Now(),
+	}
+
+	ormEvents := ToGormAuditEvents([]*domain.AuditEvent{domainEvent})
+
+	assert.Len(t, ormEvents, 1)
+	assert.Equal(t, "user", ormEvents[0].ActorType)
+	assert.Equal(t, "update", ormEvents[0].Action)
+	assert.Equal(t, "farm", ormEvents[0].ResourceType)
+	assert.Equal(t, domainEvent, ToDomainAuditEvent(&ormEvents[0]))
+}
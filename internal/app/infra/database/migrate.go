@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/migrations"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"gorm.io/gorm"
+)
+
+// DefaultOrganizationSetting holds the organization the migrations assign the farms without an organization to
+const DefaultOrganizationSetting = "farm_api.default_organization_id"
+
+// NewMigrator returns a migrator of the embedded migrations for the database, with the settings of the configuration
+// read by the data migrations
+func NewMigrator(db *gorm.DB, cfg config.DatabaseConfig) (*migrations.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+	migrator.Set(DefaultOrganizationSetting, cfg.DefaultOrganizationID)
+	return migrator, nil
+}
+
+// migrateOnBoot applies the pending migrations, or refuses to start while migrations are pending, depending on the migration mode
+func migrateOnBoot(db *gorm.DB, cfg *config.Config, logger *logger.Logger) error {
+	ctx := context.Background()
+	migrator, err := NewMigrator(db, cfg.Database)
+	if err != nil {
+		return err
+	}
+	switch cfg.Database.MigrationMode {
+	case "", config.MigrationModeApply:
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			logger.Info(ctx, "Applied database migration", map[string]interface{}{"version": migration.Version, "name": migration.Name})
+		}
+		return err
+	case config.MigrationModeVerify:
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("the database schema is out of date, %d migrations are pending starting at %d_%s, run the migrate up command", len(pending), pending[0].Version, pending[0].Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid database migration mode %q, it must be either %q or %q", cfg.Database.MigrationMode, config.MigrationModeApply, config.MigrationModeVerify)
+	}
+}
@@ -0,0 +1,310 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed sql/*.sql
+var embeddedFiles embed.FS
+
+// advisoryLockKey identifies the PostgreSQL advisory lock held while migrating,
+// so the replicas starting together don't apply the same migrations concurrently
+const advisoryLockKey int64 = 4_175_820_913
+
+var (
+	ErrInvalidMigrationFile = errors.New("invalid migration file")
+	ErrUnknownMigration     = errors.New("the database has a migration that is not known by this version")
+)
+
+// migrationFileName matches the <version>_<name>.<up|down>.sql migration files
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change, Down reverts the changes made by Up
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a known migration along with the time it was applied at, AppliedAt is nil for pending migrations
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	settings   map[string]string
+}
+
+// LoadMigrations reads the migrations of a directory, sorted by version. Every migration must have an up file,
+// the down file is optional and the migration can't be reverted without it.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	migrationsByVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("%w: %s doesn't follow the <version>_<name>.<up|down>.sql pattern", ErrInvalidMigrationFile, entry.Name())
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMigrationFile, entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration, exists := migrationsByVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: matches[2]}
+			migrationsByVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrInvalidMigrationFile, version, migration.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(migrationsByVersion))
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: migration %d_%s has no up file", ErrInvalidMigrationFile, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// NewMigrator returns a migrator of the migrations embedded in the binary
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations(embeddedFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return NewMigratorWithMigrations(db, migrations), nil
+}
+
+func NewMigratorWithMigrations(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		settings:   map[string]string{},
+	}
+}
+
+// Set sets a PostgreSQL setting in the transactions of the migrations, which read it with current_setting(name, true).
+// It passes the configuration values needed by the data migrations, the name must be qualified, such as farm_api.name
+func (m *Migrator) Set(name string, value string) {
+	m.settings[name] = value
+}
+
+// withLock runs fn on a single connection holding the migrations advisory lock,
+// the schema_migrations table is created before fn runs
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire the migrations lock: %w", err)
+	}
+	defer func() {
+		// the lock is released with the session anyway, failing to release it here only delays the other replicas
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockKey); unlockErr != nil && err == nil {
+			err = fmt.Errorf("failed to release the migrations lock: %w", unlockErr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint NOT NULL PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// queryer is either the connection holding the migrations lock or the connection pool
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// appliedMigrations returns the time each applied migration version was applied at
+func appliedMigrations(ctx context.Context, conn queryer) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// run executes the migration statements and records the change in schema_migrations in a single transaction,
+// the settings are local to the transaction
+func run(ctx context.Context, conn *sql.Conn, settings map[string]string, statements string, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for name, value := range settings {
+		if _, err := tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", name, value); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Up applies the pending migrations in version order and returns them, it stops at the first failing migration
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedAt, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, exists := appliedAt[migration.Version]; exists {
+				continue
+			}
+			if err := run(ctx, conn, m.settings, migration.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedAt, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		versions := make([]int64, 0, len(appliedAt))
+		for version := range appliedAt {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i] > versions[j]
+		})
+		if steps < len(versions) {
+			versions = versions[:steps]
+		}
+		for _, version := range versions {
+			migration, exists := m.migration(version)
+			if !exists {
+				return fmt.Errorf("%w: %d", ErrUnknownMigration, version)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file and can't be reverted", migration.Version, migration.Name)
+			}
+			if err := run(ctx, conn, m.settings, migration.Down, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns every known migration with the time it was applied at, in version order. It only reads the database,
+// without taking the migrations lock, so it can check a read-only database. Every migration is pending when the
+// schema_migrations table doesn't exist yet.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var tableExists bool
+	if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&tableExists); err != nil {
+		return nil, err
+	}
+	appliedAt := map[int64]time.Time{}
+	if tableExists {
+		var err error
+		if appliedAt, err = appliedMigrations(ctx, m.db); err != nil {
+			return nil, err
+		}
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if at, exists := appliedAt[migration.Version]; exists {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that are not applied yet, in version order
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) migration(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
@@ -0,0 +1,266 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_farms", Up: "CREATE TABLE farms (id uuid)", Down: "DROP TABLE farms"},
+	{Version: 2, Name: "add_farm_name", Up: "ALTER TABLE farms ADD COLUMN name text", Down: "ALTER TABLE farms DROP COLUMN name"},
+	{Version: 3, Name: "add_farm_address", Up: "ALTER TABLE farms ADD COLUMN address text"},
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/000002_add_farm_name.up.sql":    {Data: []byte("ALTER TABLE farms ADD COLUMN name text")},
+		"sql/000002_add_farm_name.down.sql":  {Data: []byte("ALTER TABLE farms DROP COLUMN name")},
+		"sql/000001_create_farms.up.sql":     {Data: []byte("CREATE TABLE farms (id uuid)")},
+		"sql/000001_create_farms.down.sql":   {Data: []byte("DROP TABLE farms")},
+		"sql/000010_add_farm_address.up.sql": {Data: []byte("ALTER TABLE farms ADD COLUMN address text")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "sql")
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "create_farms", Up: "CREATE TABLE farms (id uuid)", Down: "DROP TABLE farms"},
+		{Version: 2, Name: "add_farm_name", Up: "ALTER TABLE farms ADD COLUMN name text", Down: "ALTER TABLE farms DROP COLUMN name"},
+		{Version: 10, Name: "add_farm_address", Up: "ALTER TABLE farms ADD COLUMN address text"},
+	}, migrations)
+}
+
+func TestLoadInvalidMigrations(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "Unversioned file",
+			files: fstest.MapFS{"sql/create_farms.up.sql": {Data: []byte("CREATE TABLE farms (id uuid)")}},
+		},
+		{
+			name:  "Missing direction",
+			files: fstest.MapFS{"sql/000001_create_farms.sql": {Data: []byte("CREATE TABLE farms (id uuid)")}},
+		},
+		{
+			name:  "Missing up file",
+			files: fstest.MapFS{"sql/000001_create_farms.down.sql": {Data: []byte("DROP TABLE farms")}},
+		},
+		{
+			name: "Duplicated version",
+			files: fstest.MapFS{
+				"sql/000001_create_farms.up.sql": {Data: []byte("CREATE TABLE farms (id uuid)")},
+				"sql/000001_create_crops.up.sql": {Data: []byte("CREATE TABLE crops (id uuid)")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.files, "sql")
+			assert.ErrorIs(t, err, ErrInvalidMigrationFile)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(embeddedFiles, "sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, migration := range migrations {
+		// the versions are sequential so two branches adding the same version conflict on the file names
+		assert.Equal(t, int64(i+1), migration.Version)
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down file", migration.Version, migration.Name)
+	}
+}
+
+// expectLock expects the migrations lock to be taken and the schema_migrations table to be created,
+// followed by the query of the applied migrations
+func expectLock(mock sqlmock.Sqlmock, appliedVersions ...int64) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+		WithArgs(advisoryLockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range appliedVersions {
+		rows.AddRow(version, time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations ORDER BY version")).
+		WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).
+		WithArgs(advisoryLockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestUp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1)
+	for _, migration := range testMigrations[1:] {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+			WithArgs(migration.Version, migration.Name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	expectUnlock(mock)
+
+	applied, err := NewMigratorWithMigrations(db, testMigrations).Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testMigrations[1:], applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpSetsTheSettingsInEachMigration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, true)")).
+		WithArgs("farm_api.default_organization_id", "8d7a4f5e-4a1c-4c07-9a3b-2f1e0c6d5b4a").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(testMigrations[2].Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(testMigrations[2].Version, testMigrations[2].Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	migrator := NewMigratorWithMigrations(db, testMigrations)
+	migrator.Set("farm_api.default_organization_id", "8d7a4f5e-4a1c-4c07-9a3b-2f1e0c6d5b4a")
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testMigrations[2:], applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpStopsAtTheFailingMigration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(testMigrations[1].Up)).WillReturnError(errors.New("column already exists"))
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := NewMigratorWithMigrations(db, testMigrations).Up(context.Background())
+	assert.EqualError(t, err, "failed to apply migration 2_add_farm_name: column already exists")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(testMigrations[1].Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = $1")).
+		WithArgs(testMigrations[1].Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := NewMigratorWithMigrations(db, testMigrations).Down(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{testMigrations[1]}, reverted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDownWithoutDownFile(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1, 2, 3)
+	expectUnlock(mock)
+
+	reverted, err := NewMigratorWithMigrations(db, testMigrations).Down(context.Background(), 1)
+	assert.EqualError(t, err, "migration 3_add_farm_address has no down file and can't be reverted")
+	assert.Empty(t, reverted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDownOfAnUnknownMigration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectLock(mock, 1, 2, 4)
+	expectUnlock(mock)
+
+	_, err = NewMigratorWithMigrations(db, testMigrations).Down(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrUnknownMigration)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// expectStatus expects the read-only queries of the migrations status, the applied migrations are only queried
+// when the schema_migrations table exists
+func expectStatus(mock sqlmock.Sqlmock, tableExists bool, appliedVersions ...int64) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('schema_migrations') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tableExists))
+	if !tableExists {
+		return
+	}
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range appliedVersions {
+		rows.AddRow(version, time.Now())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations ORDER BY version")).
+		WillReturnRows(rows)
+}
+
+func TestStatusAndPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	migrator := NewMigratorWithMigrations(db, testMigrations)
+
+	// the status neither takes the migrations lock nor creates the schema_migrations table
+	expectStatus(mock, true, 1, 2)
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.NotNil(t, statuses[1].AppliedAt)
+	assert.Nil(t, statuses[2].AppliedAt)
+
+	expectStatus(mock, true, 1, 2)
+	pending, err := migrator.Pending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testMigrations[2:], pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatusOfAFreshDatabase(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	migrator := NewMigratorWithMigrations(db, testMigrations)
+
+	expectStatus(mock, false)
+	pending, err := migrator.Pending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testMigrations, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS crop_productions;
+DROP TABLE IF EXISTS farms;
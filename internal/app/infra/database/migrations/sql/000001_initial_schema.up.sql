@@ -0,0 +1,68 @@
+-- The schema created by AutoMigrate before the versioned migrations. The IF NOT EXISTS clauses let the databases
+-- created by AutoMigrate adopt the migrations: their farms table predates the organization_id and land_area_hectares
+-- columns, which are added before they are indexed, and the land areas are backfilled by the next migration.
+CREATE TABLE IF NOT EXISTS farms (
+    id uuid NOT NULL,
+    organization_id uuid,
+    name varchar(255) NOT NULL,
+    land_area decimal NOT NULL,
+    land_area_hectares decimal NOT NULL DEFAULT 0,
+    unit_measure varchar(50) NOT NULL,
+    address varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE farms ADD COLUMN IF NOT EXISTS organization_id uuid;
+ALTER TABLE farms ADD COLUMN IF NOT EXISTS land_area_hectares decimal NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_farms_organization_id ON farms (organization_id);
+CREATE INDEX IF NOT EXISTS idx_farms_land_area_hectares ON farms (land_area_hectares);
+CREATE INDEX IF NOT EXISTS idx_farms_deleted_at ON farms (deleted_at);
+
+CREATE TABLE IF NOT EXISTS crop_productions (
+    id uuid NOT NULL,
+    farm_id uuid NOT NULL,
+    crop_type varchar(50) NOT NULL,
+    is_irrigated boolean NOT NULL,
+    is_insured boolean NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    deleted_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_farms_crop_productions FOREIGN KEY (farm_id) REFERENCES farms (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_crop_productions_deleted_at ON crop_productions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id uuid NOT NULL,
+    organization_id uuid,
+    name varchar(255) NOT NULL,
+    key_hash varchar(64) NOT NULL,
+    roles varchar(255) NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys (organization_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS idx_api_keys_revoked_at ON api_keys (revoked_at);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+    id uuid NOT NULL,
+    organization_id uuid NOT NULL,
+    actor_id varchar(255) NOT NULL,
+    actor_type varchar(50) NOT NULL,
+    action varchar(50) NOT NULL,
+    resource_type varchar(50) NOT NULL,
+    resource_id varchar(255) NOT NULL,
+    request_id varchar(255) NOT NULL DEFAULT '',
+    before jsonb,
+    after jsonb,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_audit_events_organization_id ON audit_events (organization_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_resource ON audit_events (resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
@@ -0,0 +1 @@
+-- The backfilled land areas are valid for the previous schema as well, there is nothing to undo.
@@ -0,0 +1,40 @@
+-- Normalizes the unit measure and fills the hectare land area of the farms stored before the unit measures
+-- were normalized, following domain.ParseUnitMeasure. Farms with unknown unit measures are left untouched.
+WITH normalized AS (
+    SELECT id, replace(replace(lower(trim(unit_measure)), ' ', '_'), '-', '_') AS unit_measure
+    FROM farms
+    WHERE land_area_hectares = 0 AND land_area <> 0
+), canonical AS (
+    SELECT id,
+        CASE unit_measure
+            WHEN 'ha' THEN 'hectares'
+            WHEN 'hectare' THEN 'hectares'
+            WHEN 'ac' THEN 'acres'
+            WHEN 'acre' THEN 'acres'
+            WHEN 'm2' THEN 'square_meters'
+            WHEN 'm²' THEN 'square_meters'
+            WHEN 'square_meter' THEN 'square_meters'
+            WHEN 'km2' THEN 'square_kilometers'
+            WHEN 'km²' THEN 'square_kilometers'
+            WHEN 'square_kilometer' THEN 'square_kilometers'
+            WHEN 'alqueire' THEN 'alqueires_paulista'
+            WHEN 'alqueires' THEN 'alqueires_paulista'
+            WHEN 'alqueire_paulista' THEN 'alqueires_paulista'
+            WHEN 'alqueire_mineiro' THEN 'alqueires_mineiro'
+            ELSE unit_measure
+        END AS unit_measure
+    FROM normalized
+)
+UPDATE farms
+SET unit_measure = canonical.unit_measure,
+    land_area_hectares = farms.land_area * CASE canonical.unit_measure
+        WHEN 'hectares' THEN 1
+        WHEN 'acres' THEN 0.40468564224
+        WHEN 'square_meters' THEN 0.0001
+        WHEN 'square_kilometers' THEN 100
+        WHEN 'alqueires_paulista' THEN 2.42
+        WHEN 'alqueires_mineiro' THEN 4.84
+    END
+FROM canonical
+WHERE farms.id = canonical.id
+    AND canonical.unit_measure IN ('hectares', 'acres', 'square_meters', 'square_kilometers', 'alqueires_paulista', 'alqueires_mineiro');
@@ -0,0 +1,2 @@
+-- The farms keep the organization they were assigned to
+ALTER TABLE farms ALTER COLUMN organization_id DROP NOT NULL;
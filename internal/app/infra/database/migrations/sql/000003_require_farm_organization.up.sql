@@ -0,0 +1,15 @@
+-- Assigns the farms stored before the organizations were introduced to the default organization, set with
+-- database.default_organization_id (DB_DEFAULT_ORGANIZATION_ID), then requires every farm to have an organization.
+-- The migration fails when such farms exist and no default organization is configured.
+DO $$
+DECLARE
+    default_organization_id text := coalesce(current_setting('farm_api.default_organization_id', true), '');
+BEGIN
+    IF EXISTS (SELECT 1 FROM farms WHERE organization_id IS NULL) THEN
+        IF default_organization_id = '' THEN
+            RAISE EXCEPTION 'farms without an organization exist, set database.default_organization_id (DB_DEFAULT_ORGANIZATION_ID) to assign them to an organization';
+        END IF;
+        UPDATE farms SET organization_id = default_organization_id::uuid WHERE organization_id IS NULL;
+    END IF;
+END $$;
+ALTER TABLE farms ALTER COLUMN organization_id SET NOT NULL;
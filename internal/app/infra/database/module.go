@@ -1,16 +1,50 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"time"
+
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/repositories"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
+// newDatabase connects to the configured database and closes it when the application stops
+func newDatabase(lifecycle fx.Lifecycle, config *config.Config, logger *logger.Logger) (*gorm.DB, error) {
+	ctx := context.Background()
+	db, err := NewPostgresDatabase(ctx, ConnectionString(config), DefaultRetryPolicy, func(attempt int, delay time.Duration, err error) {
+		logger.Warn(ctx, "The database is not available yet, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	ConfigurePool(sqlDB, config)
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return sqlDB.Close()
+		},
+	})
+	return db, nil
+}
+
+// newSQLDatabase returns the connection pool of the database, used by the readiness check
+func newSQLDatabase(db *gorm.DB) (*sql.DB, error) {
+	return db.DB()
+}
+
 var Module = fx.Options(
-	fx.Provide(func(config *config.Config) *gorm.DB {
-		connectionString := generateConnectionString(config)
-		return NewPostgresDatabase(connectionString)
-	}),
+	fx.Provide(newDatabase, newSQLDatabase),
+	fx.Invoke(migrateOnBoot),
 	repositories.Module,
 )
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/mappers"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db     *gorm.DB
+	logger *logger.Logger
+}
+
+func NewAPIKeyRepository(db *gorm.DB, logger *logger.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAPIKeyByHash retrieves an API key, revoked keys included, by the hash of the key
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var ormAPIKey entities.APIKey
+	err := r.db.WithContext(ctx).First(&ormAPIKey, "key_hash = ?", keyHash).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &shared.NotFoundError{
+			Resource: "API key",
+			ID:       keyHash,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainAPIKey(&ormAPIKey), nil
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/mappers"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"gorm.io/gorm"
+)
+
+type AuditEventRepository struct {
+	db     *gorm.DB
+	logger *logger.Logger
+}
+
+func NewAuditEventRepository(db *gorm.DB, logger *logger.Logger) *AuditEventRepository {
+	return &AuditEventRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// recordAuditEvents writes the audit events of a mutation in the transaction of the mutation,
+// so a mutation is rolled back when its audit events can't be written
+func recordAuditEvents(tx *gorm.DB, events ...*domain.AuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	ormEvents := mappers.ToGormAuditEvents(events)
+	return tx.CreateInBatches(&ormEvents, 100).Error
+}
+
+// recordAuditEvent builds the audit event of a mutation made by the context principal and writes it in the transaction of the mutation
+func recordAuditEvent(ctx context.Context, tx *gorm.DB, action domain.AuditAction, resourceType domain.AuditResourceType, resourceID string, before, after interface{}) error {
+	event, err := domain.NewAuditEvent(ctx, action, resourceType, resourceID, before, after)
+	if err != nil {
+		return err
+	}
+	return recordAuditEvents(tx, event)
+}
+
+func (r *AuditEventRepository) ListAuditEvents(ctx context.Context, searchParameters *domain.AuditEventSearchParameters) (*models.PaginatedResponse[*domain.AuditEvent], error) {
+	r.logger.Info(ctx, "Querying audit events")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := r.db.WithContext(ctx).Model(&entities.AuditEvent{}).Where("organization_id = ?", organizationID)
+	if searchParameters.ResourceType != nil {
+		query = query.Where("resource_type = ?", *searchParameters.ResourceType)
+	}
+	if searchParameters.ResourceID != nil {
+		query = query.Where("resource_id = ?", *searchParameters.ResourceID)
+	}
+	if searchParameters.ActorID != nil {
+		query = query.Where("actor_id = ?", *searchParameters.ActorID)
+	}
+	if searchParameters.From != nil {
+		query = query.Where("created_at >= ?", *searchParameters.From)
+	}
+	if searchParameters.To != nil {
+		query = query.Where("created_at < ?", *searchParameters.To)
+	}
+
+	var totalCount int64
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, err
+	}
+	if searchParameters.Page < 1 {
+		searchParameters.Page = 1
+	}
+	if searchParameters.PerPage < 1 {
+		searchParameters.PerPage = 10
+	}
+	var ormEvents []entities.AuditEvent
+	if err := query.
+		Order("created_at DESC, id").
+		Offset((searchParameters.Page - 1) * searchParameters.PerPage).
+		Limit(searchParameters.PerPage).
+		Find(&ormEvents).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.AuditEvent, 0, len(ormEvents))
+	for i := range ormEvents {
+		events = append(events, mappers.ToDomainAuditEvent(&ormEvents[i]))
+	}
+	return &models.PaginatedResponse[*domain.AuditEvent]{
+		Items:       events,
+		TotalCount:  totalCount,
+		CurrentPage: searchParameters.Page,
+		PerPage:     searchParameters.PerPage,
+	}, nil
+}
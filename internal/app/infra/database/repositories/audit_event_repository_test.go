@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/arthurgavazza/farm-api-challenge/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// expectAuditEvent expects the audit event of a mutation made by the "user-1" principal of the repository suites
+func expectAuditEvent(mock sqlmock.Sqlmock, organizationID uuid.UUID, action domain.AuditAction, resourceType domain.AuditResourceType, resourceID string) {
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_events" ("id","organization_id","actor_id","actor_type","action","resource_type","resource_id","request_id","before","after","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`)).
+		WithArgs(
+			sqlmock.AnyArg(),
+			organizationID,
+			"user-1",
+			"",
+			string(action),
+			string(resourceType),
+			resourceID,
+			"",
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			testutils.AnyTime{},
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+type AuditEventRepositoryTestSuite struct {
+	suite.Suite
+	conn *sql.DB
+	DB   *gorm.DB
+	mock sqlmock.Sqlmock
+
+	repo           *AuditEventRepository
+	organizationID uuid.UUID
+	ctx            context.Context
+}
+
+func (rs *AuditEventRepositoryTestSuite) SetupSuite() {
+	var (
+		err error
+	)
+
+	rs.conn, rs.mock, err = sqlmock.New()
+	assert.NoError(rs.T(), err)
+
+	dialector := postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 rs.conn,
+		PreferSimpleProtocol: true,
+	})
+
+	rs.DB, err = gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(rs.T(), err)
+	rs.repo = NewAuditEventRepository(rs.DB, logger.NewLogger())
+	rs.organizationID = uuid.New()
+	rs.ctx = domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1", OrganizationID: rs.organizationID})
+}
+
+func (rs *AuditEventRepositoryTestSuite) AfterTest(_, _ string) {
+	assert.NoError(rs.T(), rs.mock.ExpectationsWereMet())
+}
+
+func (rs *AuditEventRepositoryTestSuite) TestListAuditEventsWithFilters() {
+	resourceType := domain.AuditResourceFarm
+	resourceID := uuid.New().String()
+	actorID := "user-2"
+	from := time.Now().Add(-24 * time.Hour)
+	to := time.Now()
+	eventID := uuid.New()
+
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "audit_events" WHERE organization_id = $1 AND resource_type = $2 AND resource_id = $3 AND actor_id = $4 AND created_at >= $5 AND created_at < $6`)).
+		WithArgs(rs.organizationID, resourceType, resourceID, actorID, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_events" WHERE organization_id = $1 AND resource_type = $2 AND resource_id = $3 AND actor_id = $4 AND created_at >= $5 AND created_at < $6 ORDER BY created_at DESC, id LIMIT $7 OFFSET $8`)).
+		WithArgs(rs.organizationID, resourceType, resourceID, actorID, from, to, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "organization_id", "actor_id", "actor_type", "action", "resource_type", "resource_id", "request_id", "before", "after", "created_at"}).
+			AddRow(eventID, rs.organizationID, actorID, "user", "delete", "farm", resourceID, "request-1", []byte(`{"name":"Farm"}`), nil, to.Add(-time.Hour)))
+
+	events, err := rs.repo.ListAuditEvents(rs.ctx, &domain.AuditEventSearchParameters{
+		ResourceType: &resourceType,
+		ResourceID:   &resourceID,
+		ActorID:      &actorID,
+		From:         &from,
+		To:           &to,
+		Page:         2,
+		PerPage:      10,
+	})
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), int64(11), events.TotalCount)
+	assert.Len(rs.T(), events.Items, 1)
+	assert.Equal(rs.T(), eventID, events.Items[0].ID)
+	assert.Equal(rs.T(), domain.AuditActionDelete, events.Items[0].Action)
+	assert.JSONEq(rs.T(), `{"name":"Farm"}`, string(events.Items[0].Before))
+	assert.Nil(rs.T(), events.Items[0].After)
+}
+
+func (rs *AuditEventRepositoryTestSuite) TestListAuditEventsDefaultsPagination() {
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "audit_events" WHERE organization_id = $1`)).
+		WithArgs(rs.organizationID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "audit_events" WHERE organization_id = $1 ORDER BY created_at DESC, id LIMIT $2`)).
+		WithArgs(rs.organizationID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	events, err := rs.repo.ListAuditEvents(rs.ctx, &domain.AuditEventSearchParameters{})
+	assert.NoError(rs.T(), err)
+	assert.Empty(rs.T(), events.Items)
+	assert.Equal(rs.T(), 1, events.CurrentPage)
+	assert.Equal(rs.T(), 10, events.PerPage)
+}
+
+func (rs *AuditEventRepositoryTestSuite) TestAuditEventsRequireAnOrganization() {
+	_, err := rs.repo.ListAuditEvents(context.Background(), &domain.AuditEventSearchParameters{})
+	assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+}
+
+func TestAuditEventRepositorySuite(t *testing.T) {
+	suite.Run(t, new(AuditEventRepositoryTestSuite))
+}
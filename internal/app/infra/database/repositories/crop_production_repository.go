@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/entities"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/database/mappers"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CropProductionRepository struct {
+	db     *gorm.DB
+	logger *logger.Logger
+}
+
+func NewCropProductionRepository(db *gorm.DB, logger *logger.Logger) *CropProductionRepository {
+	return &CropProductionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ensureFarmExists returns a NotFoundError when the farm doesn't exist, was deleted or belongs to another organization
+func (r *CropProductionRepository) ensureFarmExists(tx *gorm.DB, organizationID uuid.UUID, farmId string) error {
+	var count int64
+	if err := tx.Model(&entities.Farm{}).Where("id = ? AND organization_id = ?", farmId, organizationID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return &shared.NotFoundError{
+			Resource: "Farm",
+			ID:       farmId,
+		}
+	}
+	return nil
+}
+
+// findCropProduction returns a NotFoundError when the crop production doesn't exist or its farm was deleted or belongs to another organization
+func (r *CropProductionRepository) findCropProduction(tx *gorm.DB, organizationID uuid.UUID, farmId string, cropProductionId string) (*entities.CropProduction, error) {
+	var ormCropProduction entities.CropProduction
+	err := tx.
+		Joins("JOIN farms ON farms.id = crop_productions.farm_id AND farms.deleted_at IS NULL AND farms.organization_id = ?", organizationID).
+		Where("crop_productions.id = ? AND crop_productions.farm_id = ?", cropProductionId, farmId).
+		First(&ormCropProduction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &shared.NotFoundError{
+			Resource: "CropProduction",
+			ID:       cropProductionId,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ormCropProduction, nil
+}
+
+func (r *CropProductionRepository) ListCropProductions(ctx context.Context, farmId string) ([]domain.CropProduction, error) {
+	r.logger.Info(ctx, "Listing crop productions", map[string]interface{}{"farmId": farmId})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := r.db.WithContext(ctx)
+	if err := r.ensureFarmExists(db, organizationID, farmId); err != nil {
+		return nil, err
+	}
+	var ormCropProductions []entities.CropProduction
+	if err := db.Where("farm_id = ?", farmId).Order("created_at").Find(&ormCropProductions).Error; err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainCropProductions(ormCropProductions), nil
+}
+
+func (r *CropProductionRepository) GetCropProduction(ctx context.Context, farmId string, cropProductionId string) (*domain.CropProduction, error) {
+	r.logger.Info(ctx, "Retrieving crop production", map[string]interface{}{"farmId": farmId, "cropProductionId": cropProductionId})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ormCropProduction, err := r.findCropProduction(r.db.WithContext(ctx), organizationID, farmId, cropProductionId)
+	if err != nil {
+		return nil, err
+	}
+	cropProduction := mappers.ToDomainCropProductions([]entities.CropProduction{*ormCropProduction})[0]
+	return &cropProduction, nil
+}
+
+func (r *CropProductionRepository) CreateCropProduction(ctx context.Context, cropProduction *domain.CropProduction) (*domain.CropProduction, error) {
+	r.logger.Info(ctx, "Creating crop production", map[string]interface{}{"farmId": cropProduction.FarmID.String()})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ormCropProduction := mappers.ToGormCropProductions([]domain.CropProduction{*cropProduction})[0]
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.ensureFarmExists(tx, organizationID, cropProduction.FarmID.String()); err != nil {
+			return err
+		}
+		if err := tx.Create(&ormCropProduction).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, domain.AuditActionCreate, domain.AuditResourceCropProduction, cropProduction.ID.String(), nil, cropProduction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cropProduction, nil
+}
+
+func (r *CropProductionRepository) UpdateCropProduction(ctx context.Context, cropProduction *domain.CropProduction) (*domain.CropProduction, error) {
+	return r.updateCropProduction(ctx, cropProduction.FarmID.String(), cropProduction.ID.String(), func(domain.CropProduction) (*domain.CropProduction, error) {
+		return cropProduction, nil
+	})
+}
+
+// PatchCropProduction applies the patch to the stored crop production, the crop production is locked from its read to its
+// update so concurrent patches of the same crop production don't overwrite each other's changes
+func (r *CropProductionRepository) PatchCropProduction(ctx context.Context, farmId string, cropProductionId string, patch domain.CropProductionPatch) (*domain.CropProduction, error) {
+	return r.updateCropProduction(ctx, farmId, cropProductionId, patch.Apply)
+}
+
+// updateCropProduction reads the crop production with a row lock and stores the crop production returned by update
+// in the same transaction, an error returned by update rolls the transaction back
+func (r *CropProductionRepository) updateCropProduction(
+	ctx context.Context,
+	farmId string,
+	cropProductionId string,
+	update func(stored domain.CropProduction) (*domain.CropProduction, error),
+) (*domain.CropProduction, error) {
+	r.logger.Info(ctx, "Updating crop production", map[string]interface{}{"cropProductionId": cropProductionId})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cropProduction *domain.CropProduction
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// only the crop production row is locked, not the row of its farm
+		locked := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "crop_productions"}})
+		existingCropProduction, err := r.findCropProduction(locked, organizationID, farmId, cropProductionId)
+		if err != nil {
+			return err
+		}
+		before := mappers.ToDomainCropProductions([]entities.CropProduction{*existingCropProduction})[0]
+		if cropProduction, err = update(before); err != nil {
+			return err
+		}
+		if err := tx.
+			Model(&entities.CropProduction{}).
+			Where("id = ?", existingCropProduction.ID).
+			Updates(map[string]interface{}{
+				"crop_type":    cropProduction.CropType,
+				"is_irrigated": cropProduction.IsIrrigated,
+				"is_insured":   cropProduction.IsInsured,
+			}).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, domain.AuditActionUpdate, domain.AuditResourceCropProduction, cropProductionId, before, cropProduction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cropProduction, nil
+}
+
+func (r *CropProductionRepository) DeleteCropProduction(ctx context.Context, farmId string, cropProductionId string) error {
+	log := r.logger.With(map[string]interface{}{"farmId": farmId, "cropProductionId": cropProductionId})
+	log.Info(ctx, "Deleting crop production")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existingCropProduction, err := r.findCropProduction(tx, organizationID, farmId, cropProductionId)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&entities.CropProduction{}, "id = ?", cropProductionId).Error; err != nil {
+			return err
+		}
+		before := mappers.ToDomainCropProductions([]entities.CropProduction{*existingCropProduction})[0]
+		return recordAuditEvent(ctx, tx, domain.AuditActionDelete, domain.AuditResourceCropProduction, cropProductionId, before, nil)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info(ctx, "Crop production deleted successfully")
+	return nil
+}
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/arthurgavazza/farm-api-challenge/testutils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type CropProductionRepositoryTestSuite struct {
+	suite.Suite
+	conn *sql.DB
+	DB   *gorm.DB
+	mock sqlmock.Sqlmock
+
+	repo           *CropProductionRepository
+	cropProduction *domain.CropProduction
+	organizationID uuid.UUID
+	ctx            context.Context
+}
+
+func (rs *CropProductionRepositoryTestSuite) SetupSuite() {
+	var (
+		err error
+	)
+
+	rs.conn, rs.mock, err = sqlmock.New()
+	assert.NoError(rs.T(), err)
+
+	dialector := postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 rs.conn,
+		PreferSimpleProtocol: true,
+	})
+
+	rs.DB, err = gorm.Open(dialector, &gorm.Config{})
+	assert.NoError(rs.T(), err)
+	rs.repo = NewCropProductionRepository(rs.DB, logger.NewLogger())
+	rs.organizationID = uuid.New()
+	rs.ctx = domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1", OrganizationID: rs.organizationID})
+	rs.cropProduction, err = domain.NewCropProduction(uuid.New(), uuid.New(), domain.CropTypeSoybean, true, false)
+	assert.NoError(rs.T(), err)
+}
+
+func (rs *CropProductionRepositoryTestSuite) AfterTest(_, _ string) {
+	assert.NoError(rs.T(), rs.mock.ExpectationsWereMet())
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestCreateCropProduction() {
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "farms" WHERE (id = $1 AND organization_id = $2) AND "farms"."deleted_at" IS NULL`)).
+		WithArgs(rs.cropProduction.FarmID.String(), rs.organizationID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "crop_productions"`)).
+		WithArgs(
+			rs.cropProduction.ID,
+			rs.cropProduction.FarmID,
+			rs.cropProduction.CropType,
+			rs.cropProduction.IsIrrigated,
+			rs.cropProduction.IsInsured,
+			testutils.AnyTime{},
+			testutils.AnyTime{},
+			nil,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionCreate, domain.AuditResourceCropProduction, rs.cropProduction.ID.String())
+	rs.mock.ExpectCommit()
+
+	cropProduction, err := rs.repo.CreateCropProduction(rs.ctx, rs.cropProduction)
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), rs.cropProduction.ID, cropProduction.ID)
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestCreateCropProductionForMissingFarm() {
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "farms"`)).
+		WithArgs(rs.cropProduction.FarmID.String(), rs.organizationID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	rs.mock.ExpectRollback()
+
+	cropProduction, err := rs.repo.CreateCropProduction(rs.ctx, rs.cropProduction)
+	expectedErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       rs.cropProduction.FarmID.String(),
+	}
+	assert.Nil(rs.T(), cropProduction)
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestListCropProductions() {
+	farmId := rs.cropProduction.FarmID.String()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "farms"`)).
+		WithArgs(farmId, rs.organizationID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE farm_id = $1 AND "crop_productions"."deleted_at" IS NULL ORDER BY created_at`)).
+		WithArgs(farmId).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(rs.cropProduction.ID, rs.cropProduction.FarmID, rs.cropProduction.CropType, true, false))
+
+	cropProductions, err := rs.repo.ListCropProductions(rs.ctx, farmId)
+	assert.NoError(rs.T(), err)
+	assert.Len(rs.T(), cropProductions, 1)
+	assert.Equal(rs.T(), rs.cropProduction.ID, cropProductions[0].ID)
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestDeleteNonExistingCropProduction() {
+	farmId := rs.cropProduction.FarmID.String()
+	cropProductionId := uuid.New().String()
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`JOIN farms ON farms.id = crop_productions.farm_id AND farms.deleted_at IS NULL AND farms.organization_id = $1`)).
+		WithArgs(rs.organizationID, cropProductionId, farmId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rs.mock.ExpectRollback()
+
+	err := rs.repo.DeleteCropProduction(rs.ctx, farmId, cropProductionId)
+	expectedErr := shared.NotFoundError{
+		Resource: "CropProduction",
+		ID:       cropProductionId,
+	}
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestUpdateCropProduction() {
+	updatedCropProduction := *rs.cropProduction
+	updatedCropProduction.IsInsured = true
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "crop_productions"."id","crop_productions"."farm_id","crop_productions"."crop_type","crop_productions"."is_irrigated","crop_productions"."is_insured","crop_productions"."created_at","crop_productions"."updated_at","crop_productions"."deleted_at" FROM "crop_productions" JOIN farms ON farms.id = crop_productions.farm_id AND farms.deleted_at IS NULL AND farms.organization_id = $1`)).
+		WithArgs(rs.organizationID, rs.cropProduction.ID.String(), rs.cropProduction.FarmID.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(rs.cropProduction.ID, rs.cropProduction.FarmID, rs.cropProduction.CropType, true, false))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "crop_type"=$1,"is_insured"=$2,"is_irrigated"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(rs.cropProduction.CropType, true, true, testutils.AnyTime{}, rs.cropProduction.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionUpdate, domain.AuditResourceCropProduction, rs.cropProduction.ID.String())
+	rs.mock.ExpectCommit()
+
+	cropProduction, err := rs.repo.UpdateCropProduction(rs.ctx, &updatedCropProduction)
+	assert.NoError(rs.T(), err)
+	assert.True(rs.T(), cropProduction.IsInsured)
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestPatchCropProductionLocksTheStoredCropProduction() {
+	isInsured := true
+	rs.mock.ExpectBegin()
+	// the crop production stays locked until the patched crop production is stored, so concurrent patches are applied one after the other
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`JOIN farms ON farms.id = crop_productions.farm_id AND farms.deleted_at IS NULL AND farms.organization_id = $1 WHERE (crop_productions.id = $2 AND crop_productions.farm_id = $3) AND "crop_productions"."deleted_at" IS NULL ORDER BY "crop_productions"."id" LIMIT $4 FOR UPDATE OF "crop_productions"`)).
+		WithArgs(rs.organizationID, rs.cropProduction.ID.String(), rs.cropProduction.FarmID.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(rs.cropProduction.ID, rs.cropProduction.FarmID, rs.cropProduction.CropType, true, false))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "crop_type"=$1,"is_insured"=$2,"is_irrigated"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(rs.cropProduction.CropType, true, true, testutils.AnyTime{}, rs.cropProduction.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionUpdate, domain.AuditResourceCropProduction, rs.cropProduction.ID.String())
+	rs.mock.ExpectCommit()
+
+	cropProduction, err := rs.repo.PatchCropProduction(rs.ctx, rs.cropProduction.FarmID.String(), rs.cropProduction.ID.String(), domain.CropProductionPatch{IsInsured: &isInsured})
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), rs.cropProduction.CropType, cropProduction.CropType)
+	assert.True(rs.T(), cropProduction.IsIrrigated)
+	assert.True(rs.T(), cropProduction.IsInsured)
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestPatchCropProductionWithAnInvalidCropType() {
+	cropType := domain.CropType("TRACTOR")
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE OF "crop_productions"`)).
+		WithArgs(rs.organizationID, rs.cropProduction.ID.String(), rs.cropProduction.FarmID.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(rs.cropProduction.ID, rs.cropProduction.FarmID, rs.cropProduction.CropType, true, false))
+	rs.mock.ExpectRollback()
+
+	cropProduction, err := rs.repo.PatchCropProduction(rs.ctx, rs.cropProduction.FarmID.String(), rs.cropProduction.ID.String(), domain.CropProductionPatch{CropType: &cropType})
+	assert.Nil(rs.T(), cropProduction)
+	assert.ErrorIs(rs.T(), err, domain.ErrInvalidCropType)
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestGetCropProductionOfAnotherOrganization() {
+	farmId := rs.cropProduction.FarmID.String()
+	cropProductionId := rs.cropProduction.ID.String()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`JOIN farms ON farms.id = crop_productions.farm_id AND farms.deleted_at IS NULL AND farms.organization_id = $1`)).
+		WithArgs(rs.organizationID, cropProductionId, farmId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	cropProduction, err := rs.repo.GetCropProduction(rs.ctx, farmId, cropProductionId)
+	assert.Nil(rs.T(), cropProduction)
+	var notFoundError *shared.NotFoundError
+	assert.True(rs.T(), errors.As(err, &notFoundError))
+}
+
+func (rs *CropProductionRepositoryTestSuite) TestCropProductionsRequireAnOrganization() {
+	ctx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1"})
+	farmId := rs.cropProduction.FarmID.String()
+
+	_, err := rs.repo.ListCropProductions(ctx, farmId)
+	assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+	_, err = rs.repo.CreateCropProduction(ctx, rs.cropProduction)
+	assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+	err = rs.repo.DeleteCropProduction(context.Background(), farmId, rs.cropProduction.ID.String())
+	assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+}
+
+func TestCropProductionRepositorySuite(t *testing.T) {
+	suite.Run(t, new(CropProductionRepositoryTestSuite))
+}
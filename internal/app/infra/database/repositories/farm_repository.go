@@ -2,6 +2,9 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
@@ -12,6 +15,7 @@ import (
 	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FarmRepository struct {
@@ -27,102 +31,313 @@ func NewFarmRepository(db *gorm.DB, logger *logger.Logger) *FarmRepository {
 }
 
 type farmWithCropProduction struct {
-	FarmID      uuid.UUID  `json:"farm_id"`
-	Name        string     `json:"name"`
-	LandArea    float64    `json:"land_area"`
-	UnitMeasure string     `json:"unit_measure"`
-	Address     string     `json:"address"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-
-	CropProductionID     uuid.UUID `json:"crop_production_id"`
-	CropProductionFarmID uuid.UUID `json:"crop_production_farm_id"`
-	CropType             string    `json:"crop_type"`
-	IsIrrigated          bool      `json:"is_irrigated"`
-	IsInsured            bool      `json:"is_insured"`
+	FarmID         uuid.UUID  `json:"farm_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	Name           string     `json:"name"`
+	LandArea       float64    `json:"land_area"`
+	UnitMeasure    string     `json:"unit_measure"`
+	Address        string     `json:"address"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+
+	// the crop production columns are NULL for farms without crop productions
+	CropProductionID     *uuid.UUID `json:"crop_production_id"`
+	CropProductionFarmID *uuid.UUID `json:"crop_production_farm_id"`
+	CropType             *string    `json:"crop_type"`
+	IsIrrigated          *bool      `json:"is_irrigated"`
+	IsInsured            *bool      `json:"is_insured"`
 }
 
+// CreateFarm creates the farm in the organization of the context principal
 func (f *FarmRepository) CreateFarm(ctx context.Context, farm *domain.Farm) (*domain.Farm, error) {
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	farm.OrganizationID = organizationID
 	ormFarm := mappers.ToGormFarm(farm)
-	err := f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&ormFarm).Error; err != nil {
 			return err
 		}
-		return nil
+		farm.CreatedAt = ormFarm.CreatedAt
+		farm.UpdatedAt = ormFarm.UpdatedAt
+		return recordAuditEvent(ctx, tx, domain.AuditActionCreate, domain.AuditResourceFarm, farm.ID.String(), nil, farm)
 	})
 	if err != nil {
 		return nil, err
 	}
-	farm.CreatedAt = ormFarm.CreatedAt
-	farm.UpdatedAt = ormFarm.UpdatedAt
 	return farm, nil
 }
 
+// CreateFarms creates the farms and their crop productions in a single transaction, in the organization of the context principal
+func (f *FarmRepository) CreateFarms(ctx context.Context, farms []*domain.Farm) ([]*domain.Farm, error) {
+	f.logger.Info(ctx, "Creating farms", map[string]interface{}{"count": len(farms)})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ormFarms := make([]*entities.Farm, 0, len(farms))
+	for _, farm := range farms {
+		farm.OrganizationID = organizationID
+		ormFarms = append(ormFarms, mappers.ToGormFarm(farm))
+	}
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(ormFarms, 100).Error; err != nil {
+			return err
+		}
+		events := make([]*domain.AuditEvent, 0, len(farms))
+		for i, farm := range farms {
+			farm.CreatedAt = ormFarms[i].CreatedAt
+			farm.UpdatedAt = ormFarms[i].UpdatedAt
+			event, err := domain.NewAuditEvent(ctx, domain.AuditActionCreate, domain.AuditResourceFarm, farm.ID.String(), nil, farm)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return recordAuditEvents(tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.logger.Info(ctx, "Farms created successfully", map[string]interface{}{"count": len(farms)})
+	return farms, nil
+}
+
+func (f *FarmRepository) GetFarmByID(ctx context.Context, farmId string) (*domain.Farm, error) {
+	f.logger.Info(ctx, "Retrieving farm", map[string]interface{}{"farmId": farmId})
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ormFarm entities.Farm
+	err = f.db.WithContext(ctx).
+		Preload("CropProductions").
+		First(&ormFarm, "id = ? AND organization_id = ?", farmId, organizationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &shared.NotFoundError{
+			Resource: "Farm",
+			ID:       farmId,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ToDomainFarm(&ormFarm), nil
+}
+
+// parseRawFarmResults groups the joined rows by farm, farms are returned in the order they first appear in the rows
 func (f *FarmRepository) parseRawFarmResults(ctx context.Context, rawResults []farmWithCropProduction) []*domain.Farm {
-	f.logger.Info(ctx, "Parsing raw results from the list farms method")
+	f.logger.Debug(ctx, "Parsing raw results from the list farms method")
 	farmsMap := make(map[uuid.UUID]*domain.Farm)
+	var domainFarms []*domain.Farm
 
 	for _, row := range rawResults {
-		if farm, exists := farmsMap[row.FarmID]; exists {
-			farm.CropProductions = append(farm.CropProductions, domain.CropProduction{
-				ID:          row.CropProductionID,
-				FarmID:      row.CropProductionFarmID,
-				CropType:    row.CropType,
-				IsIrrigated: row.IsIrrigated,
-				IsInsured:   row.IsInsured,
-			})
-		} else {
-			farmsMap[row.FarmID] = &domain.Farm{
-				ID:          row.FarmID,
-				Name:        row.Name,
-				LandArea:    row.LandArea,
-				UnitMeasure: row.UnitMeasure,
-				Address:     row.Address,
-				CreatedAt:   row.CreatedAt,
-				UpdatedAt:   row.UpdatedAt,
-				DeletedAt:   row.DeletedAt,
-				CropProductions: []domain.CropProduction{
-					{
-						ID:          row.CropProductionID,
-						FarmID:      row.CropProductionFarmID,
-						CropType:    row.CropType,
-						IsIrrigated: row.IsIrrigated,
-						IsInsured:   row.IsInsured,
-					},
-				},
+		farm, exists := farmsMap[row.FarmID]
+		if !exists {
+			farm = &domain.Farm{
+				ID:             row.FarmID,
+				OrganizationID: row.OrganizationID,
+				Name:           row.Name,
+				LandArea:       row.LandArea,
+				UnitMeasure:    domain.UnitMeasure(row.UnitMeasure),
+				Address:        row.Address,
+				CreatedAt:      row.CreatedAt,
+				UpdatedAt:      row.UpdatedAt,
+				DeletedAt:      row.DeletedAt,
+
+				CropProductions: []domain.CropProduction{},
 			}
+			farmsMap[row.FarmID] = farm
+			domainFarms = append(domainFarms, farm)
+		}
+		if row.CropProductionID == nil {
+			continue
 		}
+		farm.CropProductions = append(farm.CropProductions, domain.CropProduction{
+			ID:          *row.CropProductionID,
+			FarmID:      *row.CropProductionFarmID,
+			CropType:    *row.CropType,
+			IsIrrigated: *row.IsIrrigated,
+			IsInsured:   *row.IsInsured,
+		})
 	}
 
-	var domainFarms []*domain.Farm
-	for _, farm := range farmsMap {
-		domainFarms = append(domainFarms, farm)
-	}
-	f.logger.Info(ctx, "Raw farm records parsed successfully")
+	f.logger.Debug(ctx, "Raw farm records parsed successfully")
 	return domainFarms
 }
 
-func (f *FarmRepository) ListFarms(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.PaginatedResponse[*domain.Farm], error) {
-	f.logger.Info(ctx, "Querying farms")
-	var farmIDs []string
-	var rawResults []farmWithCropProduction
-	var totalCount int64
+// cropProductionsJoin only joins the active crop productions, deleted farms keep the crop productions that were deleted with them.
+// It is a LEFT JOIN so farms without crop productions are listed as well
+const cropProductionsJoin = "LEFT JOIN crop_productions ON crop_productions.farm_id = farms.id AND " +
+	"(crop_productions.deleted_at IS NULL OR crop_productions.deleted_at = farms.deleted_at)"
 
-	baseQuery := f.db.Model(&entities.Farm{}).Joins("JOIN crop_productions ON crop_productions.farm_id = farms.id")
+// farmsQuery builds the base farms query of the organization honoring the soft delete search parameters
+func (f *FarmRepository) farmsQuery(ctx context.Context, organizationID uuid.UUID, searchParameters *domain.FarmSearchParameters) *gorm.DB {
+	query := f.db.WithContext(ctx).Model(&entities.Farm{}).Where("farms.organization_id = ?", organizationID)
+	if searchParameters.OnlyDeleted {
+		query = query.Unscoped().Where("farms.deleted_at IS NOT NULL")
+	} else if searchParameters.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	return query.Joins(cropProductionsJoin)
+}
 
-	if searchParameters.CropType != nil {
-		baseQuery = baseQuery.Where("crop_productions.crop_type = ?", *searchParameters.CropType)
+// filteredFarmsQuery applies the search filters on top of the base farms query
+func (f *FarmRepository) filteredFarmsQuery(ctx context.Context, organizationID uuid.UUID, searchParameters *domain.FarmSearchParameters) *gorm.DB {
+	query := f.farmsQuery(ctx, organizationID, searchParameters)
+
+	if searchParameters.Name != nil {
+		query = query.Where("farms.name ILIKE ?", containsPattern(*searchParameters.Name))
+	}
+	if searchParameters.Address != nil {
+		query = query.Where("farms.address ILIKE ?", containsPattern(*searchParameters.Address))
+	}
+	if searchParameters.UnitMeasure != nil {
+		query = query.Where("farms.unit_measure = ?", *searchParameters.UnitMeasure)
+	}
+	if searchParameters.CreatedAfter != nil {
+		query = query.Where("farms.created_at >= ?", *searchParameters.CreatedAfter)
+	}
+	if searchParameters.CreatedBefore != nil {
+		query = query.Where("farms.created_at < ?", *searchParameters.CreatedBefore)
+	}
+
+	// the crop production filters are applied to the same joined row, so a farm matches
+	// when one of its crop productions satisfies all of them
+	if searchParameters.IsIrrigated != nil {
+		query = query.Where("crop_productions.is_irrigated = ?", *searchParameters.IsIrrigated)
+	}
+	if searchParameters.IsInsured != nil {
+		query = query.Where("crop_productions.is_insured = ?", *searchParameters.IsInsured)
+	}
+	if len(searchParameters.CropTypes) > 0 {
+		query = query.Where("crop_productions.crop_type IN ?", searchParameters.CropTypes)
+		if searchParameters.CropTypeMatch == domain.CropTypeMatchAll {
+			query = query.Where(
+				"(?) = ?",
+				f.matchingCropTypesCount(searchParameters),
+				len(uniqueCropTypes(searchParameters.CropTypes)),
+			)
+		}
 	}
 
+	// the land area range is compared against the canonical hectare value so farms measured in different units are comparable
+	landAreaUnit := searchParameters.LandAreaUnit
+	if landAreaUnit == "" {
+		landAreaUnit = domain.UnitMeasureHectares
+	}
 	if searchParameters.MinimumLandArea != nil && searchParameters.MaximumLandArea != nil {
-		baseQuery = baseQuery.Where("farms.land_area BETWEEN ? AND ?", *searchParameters.MinimumLandArea, *searchParameters.MaximumLandArea)
+		query = query.Where(
+			"farms.land_area_hectares BETWEEN ? AND ?",
+			landAreaUnit.ToHectares(*searchParameters.MinimumLandArea),
+			landAreaUnit.ToHectares(*searchParameters.MaximumLandArea),
+		)
 	} else if searchParameters.MinimumLandArea != nil {
-		baseQuery = baseQuery.Where("farms.land_area >= ?", *searchParameters.MinimumLandArea)
+		query = query.Where("farms.land_area_hectares >= ?", landAreaUnit.ToHectares(*searchParameters.MinimumLandArea))
 	} else if searchParameters.MaximumLandArea != nil {
-		baseQuery = baseQuery.Where("farms.land_area <= ?", *searchParameters.MaximumLandArea)
+		query = query.Where("farms.land_area_hectares <= ?", landAreaUnit.ToHectares(*searchParameters.MaximumLandArea))
+	}
+	return query
+}
+
+// matchingCropTypesCount builds a subquery counting the distinct requested crop types produced by each farm
+func (f *FarmRepository) matchingCropTypesCount(searchParameters *domain.FarmSearchParameters) *gorm.DB {
+	query := f.db.Unscoped().
+		Table("crop_productions AS matched_crop_productions").
+		Select("COUNT(DISTINCT matched_crop_productions.crop_type)").
+		Where("matched_crop_productions.farm_id = farms.id").
+		Where("(matched_crop_productions.deleted_at IS NULL OR matched_crop_productions.deleted_at = farms.deleted_at)").
+		Where("matched_crop_productions.crop_type IN ?", searchParameters.CropTypes)
+	if searchParameters.IsIrrigated != nil {
+		query = query.Where("matched_crop_productions.is_irrigated = ?", *searchParameters.IsIrrigated)
 	}
-	f.logger.Info(ctx, "Counting farms")
+	if searchParameters.IsInsured != nil {
+		query = query.Where("matched_crop_productions.is_insured = ?", *searchParameters.IsInsured)
+	}
+	return query
+}
+
+func uniqueCropTypes(cropTypes []domain.CropType) map[domain.CropType]bool {
+	unique := make(map[domain.CropType]bool, len(cropTypes))
+	for _, cropType := range cropTypes {
+		unique[cropType] = true
+	}
+	return unique
+}
+
+// containsPattern builds a LIKE pattern matching values containing the given text, escaping the LIKE wildcards
+func containsPattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	return "%" + escaped + "%"
+}
+
+// applyFarmSort orders the farms by the requested fields using the farm id as a tie-breaker,
+// farms are ordered by creation date when no sort is requested
+func applyFarmSort(query *gorm.DB, sorts []domain.FarmSort) *gorm.DB {
+	if len(sorts) == 0 {
+		sorts = []domain.FarmSort{{Field: domain.FarmSortByCreatedAt}}
+	}
+	for _, farmSort := range sorts {
+		if !farmSort.Field.IsValid() {
+			continue
+		}
+		column := string(farmSort.Field)
+		// land areas are sorted by their hectare value so farms measured in different units are comparable
+		if farmSort.Field == domain.FarmSortByLandArea {
+			column = "land_area_hectares"
+		}
+		query = query.Order(clause.OrderByColumn{
+			Column: clause.Column{Table: "farms", Name: column},
+			Desc:   farmSort.Descending,
+		})
+	}
+	return query.Order(clause.OrderByColumn{Column: clause.Column{Table: "farms", Name: "id"}})
+}
+
+// loadFarms retrieves the farms with the given IDs and their crop productions, keeping the order of farmIDs
+func (f *FarmRepository) loadFarms(ctx context.Context, organizationID uuid.UUID, searchParameters *domain.FarmSearchParameters, farmIDs []string) ([]*domain.Farm, error) {
+	if len(farmIDs) == 0 {
+		return []*domain.Farm{}, nil
+	}
+	var rawResults []farmWithCropProduction
+	f.logger.Debug(ctx, "Retrieving related farms and crop productions")
+	if err := f.farmsQuery(ctx, organizationID, searchParameters).
+		Where("farms.id IN ?", farmIDs).
+		Order("crop_productions.created_at, crop_productions.id").
+		Select(`farms.id AS farm_id, farms.organization_id, farms.name, farms.land_area, farms.unit_measure, farms.address, farms.created_at, farms.updated_at, farms.deleted_at,
+                crop_productions.id AS crop_production_id, crop_productions.farm_id AS crop_production_farm_id, crop_productions.crop_type, crop_productions.is_irrigated, crop_productions.is_insured`).
+		Find(&rawResults).Error; err != nil {
+		return nil, err
+	}
+
+	farmsByID := make(map[string]*domain.Farm)
+	for _, farm := range f.parseRawFarmResults(ctx, rawResults) {
+		farmsByID[farm.ID.String()] = farm
+	}
+	domainFarms := make([]*domain.Farm, 0, len(farmIDs))
+	for _, farmID := range farmIDs {
+		if farm, exists := farmsByID[farmID]; exists {
+			domainFarms = append(domainFarms, farm)
+		}
+	}
+	return domainFarms, nil
+}
+
+func (f *FarmRepository) ListFarms(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.PaginatedResponse[*domain.Farm], error) {
+	f.logger.Debug(ctx, "Querying farms")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var farmIDs []string
+	var totalCount int64
+
+	// a new session keeps the count and the farm ids queries from leaking clauses into each other
+	baseQuery := f.filteredFarmsQuery(ctx, organizationID, searchParameters).Session(&gorm.Session{})
+	f.logger.Debug(ctx, "Counting farms")
 	if err := baseQuery.Distinct("farms.id").Count(&totalCount).Error; err != nil {
 		return nil, err
 	}
@@ -134,8 +349,8 @@ func (f *FarmRepository) ListFarms(ctx context.Context, searchParameters *domain
 	if searchParameters.PerPage < 1 {
 		searchParameters.PerPage = 10
 	}
-	f.logger.Info(ctx, "Retrieving farmIds that match the query inputs")
-	if err := baseQuery.
+	f.logger.Debug(ctx, "Retrieving farmIds that match the query inputs")
+	if err := applyFarmSort(baseQuery, searchParameters.Sort).
 		Select("farms.id").
 		Group("farms.id").
 		Offset(offset).
@@ -143,18 +358,10 @@ func (f *FarmRepository) ListFarms(ctx context.Context, searchParameters *domain
 		Pluck("farms.id", &farmIDs).Error; err != nil {
 		return nil, err
 	}
-	f.logger.Info(ctx, "Retrieving related farms and crop productions")
-	if err := f.db.Model(&entities.Farm{}).
-		Joins("JOIN crop_productions ON crop_productions.farm_id = farms.id").
-		Where("farms.id IN ?", farmIDs).
-		Select(`farms.id AS farm_id, farms.name, farms.land_area, farms.unit_measure, farms.address, farms.created_at, farms.updated_at, farms.deleted_at,
-                crop_productions.id AS crop_production_id, crop_productions.farm_id AS crop_production_farm_id, crop_productions.crop_type, crop_productions.is_irrigated, crop_productions.is_insured`).
-		Find(&rawResults).Error; err != nil {
+	domainFarms, err := f.loadFarms(ctx, organizationID, searchParameters, farmIDs)
+	if err != nil {
 		return nil, err
 	}
-
-	// Parse results and create the response
-	domainFarms := f.parseRawFarmResults(ctx, rawResults)
 	response := &models.PaginatedResponse[*domain.Farm]{
 		Items:       domainFarms,
 		TotalCount:  totalCount,
@@ -165,19 +372,419 @@ func (f *FarmRepository) ListFarms(ctx context.Context, searchParameters *domain
 	return response, nil
 }
 
+// ListFarmsByCursor pages through the farms ordered by (created_at, id) starting after the search parameters cursor.
+// Unlike ListFarms it doesn't count the matching farms and is not affected by farms created while paging.
+func (f *FarmRepository) ListFarmsByCursor(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.CursorPaginatedResponse[*domain.Farm], error) {
+	f.logger.Info(ctx, "Querying farms by cursor")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if searchParameters.Limit < 1 {
+		searchParameters.Limit = 10
+	}
+
+	query := f.filteredFarmsQuery(ctx, organizationID, searchParameters)
+	if searchParameters.Cursor != nil {
+		query = query.Where("(farms.created_at, farms.id) > (?, ?)", searchParameters.Cursor.CreatedAt, searchParameters.Cursor.ID)
+	}
+	var keys []struct {
+		ID        uuid.UUID
+		CreatedAt time.Time
+	}
+	// one extra row is fetched to know whether there is a next page
+	if err := query.
+		Select("farms.id, farms.created_at").
+		Group("farms.id").
+		Order("farms.created_at, farms.id").
+		Limit(searchParameters.Limit + 1).
+		Scan(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	var nextCursor *string
+	if len(keys) > searchParameters.Limit {
+		keys = keys[:searchParameters.Limit]
+		lastKey := keys[len(keys)-1]
+		encodedCursor := domain.FarmCursor{CreatedAt: lastKey.CreatedAt, ID: lastKey.ID}.Encode()
+		nextCursor = &encodedCursor
+	}
+	farmIDs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		farmIDs = append(farmIDs, key.ID.String())
+	}
+
+	domainFarms, err := f.loadFarms(ctx, organizationID, searchParameters, farmIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CursorPaginatedResponse[*domain.Farm]{
+		Items:      domainFarms,
+		NextCursor: nextCursor,
+		Limit:      searchParameters.Limit,
+	}, nil
+}
+
+func (f *FarmRepository) UpdateFarm(ctx context.Context, farm *domain.Farm) (*domain.Farm, error) {
+	return f.updateFarm(ctx, farm.ID.String(), func(*domain.Farm) *domain.Farm {
+		return farm
+	})
+}
+
+// PatchFarm applies the patch to the stored farm, the farm is locked from its read to its update
+// so concurrent patches of the same farm don't overwrite each other's changes
+func (f *FarmRepository) PatchFarm(ctx context.Context, farmId string, patch domain.FarmPatch) (*domain.Farm, error) {
+	return f.updateFarm(ctx, farmId, func(farm *domain.Farm) *domain.Farm {
+		patch.Apply(farm)
+		return farm
+	})
+}
+
+// updateFarm reads the farm with a row lock and stores the farm returned by update in the same transaction
+func (f *FarmRepository) updateFarm(ctx context.Context, farmId string, update func(stored *domain.Farm) *domain.Farm) (*domain.Farm, error) {
+	log := f.logger.With(map[string]interface{}{"farmId": farmId})
+	log.Info(ctx, "Updating farm")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt := time.Now()
+	var farm *domain.Farm
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingFarm entities.Farm
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("CropProductions").
+			First(&existingFarm, "id = ? AND organization_id = ?", farmId, organizationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &shared.NotFoundError{
+					Resource: "Farm",
+					ID:       farmId,
+				}
+			}
+			return err
+		}
+		farm = update(mappers.ToDomainFarm(&existingFarm))
+		if err := tx.Model(&entities.Farm{}).Where("id = ?", existingFarm.ID).Updates(map[string]interface{}{
+			"name":               farm.Name,
+			"land_area":          farm.LandArea,
+			"land_area_hectares": farm.LandAreaInHectares(),
+			"unit_measure":       farm.UnitMeasure,
+			"address":            farm.Address,
+			"updated_at":         updatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := f.reconcileCropProductions(tx, existingFarm.CropProductions, farm); err != nil {
+			return err
+		}
+		farm.OrganizationID = existingFarm.OrganizationID
+		farm.CreatedAt = existingFarm.CreatedAt
+		farm.UpdatedAt = updatedAt
+		return recordAuditEvent(ctx, tx, domain.AuditActionUpdate, domain.AuditResourceFarm, existingFarm.ID.String(), mappers.ToDomainFarm(&existingFarm), farm)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info(ctx, "Farm updated successfully")
+	return farm, nil
+}
+
+// reconcileCropProductions makes the stored crop productions of a farm match the desired ones:
+// known rows are updated, rows without an ID are inserted and rows missing from the desired list are soft deleted.
+func (f *FarmRepository) reconcileCropProductions(tx *gorm.DB, existing []entities.CropProduction, farm *domain.Farm) error {
+	existingIDs := make(map[uuid.UUID]bool, len(existing))
+	for _, crop := range existing {
+		existingIDs[crop.ID] = true
+	}
+	keptIDs := make(map[uuid.UUID]bool, len(farm.CropProductions))
+	for i := range farm.CropProductions {
+		crop := &farm.CropProductions[i]
+		crop.FarmID = farm.ID
+		if crop.ID == uuid.Nil {
+			crop.ID = uuid.New()
+			ormCrop := mappers.ToGormCropProductions([]domain.CropProduction{*crop})[0]
+			if err := tx.Create(&ormCrop).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		if !existingIDs[crop.ID] {
+			return &shared.NotFoundError{
+				Resource: "CropProduction",
+				ID:       crop.ID.String(),
+			}
+		}
+		if err := tx.Model(&entities.CropProduction{}).Where("id = ?", crop.ID).Updates(map[string]interface{}{
+			"crop_type":    crop.CropType,
+			"is_irrigated": crop.IsIrrigated,
+			"is_insured":   crop.IsInsured,
+		}).Error; err != nil {
+			return err
+		}
+		keptIDs[crop.ID] = true
+	}
+
+	var removedIDs []uuid.UUID
+	for _, crop := range existing {
+		if !keptIDs[crop.ID] {
+			removedIDs = append(removedIDs, crop.ID)
+		}
+	}
+	if len(removedIDs) == 0 {
+		return nil
+	}
+	return tx.Delete(&entities.CropProduction{}, "id IN ?", removedIDs).Error
+}
+
 func (f *FarmRepository) DeleteFarm(ctx context.Context, farmId string) error {
-	f.logger.Info(ctx, "Deleting farm", map[string]interface{}{"farmId": farmId})
-	tx := f.db.Delete(&entities.Farm{}, "id = ?", farmId)
-	if tx.Error != nil {
-		return tx.Error
+	log := f.logger.With(map[string]interface{}{"farmId": farmId})
+	log.Info(ctx, "Deleting farm")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return err
 	}
+	deletedAt := time.Now()
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingFarm entities.Farm
+		if err := tx.Preload("CropProductions").First(&existingFarm, "id = ? AND organization_id = ?", farmId, organizationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &shared.NotFoundError{
+					Resource: "Farm",
+					ID:       farmId,
+				}
+			}
+			return err
+		}
+		if err := tx.Model(&entities.Farm{}).Where("id = ?", farmId).Update("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+		// crop productions share the farm deletion timestamp so they can be restored together with it
+		if err := tx.Model(&entities.CropProduction{}).Where("farm_id = ?", farmId).Update("deleted_at", deletedAt).Error; err != nil {
+			return err
+		}
+		before := mappers.ToDomainFarm(&existingFarm)
+		after := *before
+		after.DeletedAt = &deletedAt
+		return recordAuditEvent(ctx, tx, domain.AuditActionDelete, domain.AuditResourceFarm, farmId, before, &after)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info(ctx, "Farm delete successfully")
+	return nil
+}
 
-	if tx.RowsAffected == 0 {
-		return &shared.NotFoundError{
-			Resource: "Farm",
-			ID:       farmId,
+func (f *FarmRepository) RestoreFarm(ctx context.Context, farmId string) (*domain.Farm, error) {
+	log := f.logger.With(map[string]interface{}{"farmId": farmId})
+	log.Info(ctx, "Restoring farm")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var restoredFarm entities.Farm
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deletedFarm entities.Farm
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&deletedFarm, "id = ? AND organization_id = ?", farmId, organizationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &shared.NotFoundError{
+					Resource: "Farm",
+					ID:       farmId,
+				}
+			}
+			return err
+		}
+		if err := tx.Unscoped().
+			Model(&entities.CropProduction{}).
+			Where("farm_id = ? AND deleted_at = ?", farmId, deletedFarm.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&entities.Farm{}).Where("id = ?", farmId).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Preload("CropProductions").First(&restoredFarm, "id = ?", farmId).Error; err != nil {
+			return err
 		}
+		after := mappers.ToDomainFarm(&restoredFarm)
+		before := *after
+		before.DeletedAt = &deletedFarm.DeletedAt.Time
+		return recordAuditEvent(ctx, tx, domain.AuditActionRestore, domain.AuditResourceFarm, farmId, &before, after)
+	})
+	if err != nil {
+		return nil, err
 	}
-	f.logger.Info(ctx, "Farm delete successfully", map[string]interface{}{"farmId": farmId})
+	log.Info(ctx, "Farm restored successfully")
+	return mappers.ToDomainFarm(&restoredFarm), nil
+}
+
+func (f *FarmRepository) PurgeFarm(ctx context.Context, farmId string) error {
+	log := f.logger.With(map[string]interface{}{"farmId": farmId})
+	log.Info(ctx, "Purging farm")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	err = f.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the snapshot of a purged farm holds every crop production purged with it, deleted ones included
+		var purgedFarm entities.Farm
+		if err := tx.Unscoped().
+			Preload("CropProductions", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			First(&purgedFarm, "id = ? AND organization_id = ?", farmId, organizationID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &shared.NotFoundError{
+					Resource: "Farm",
+					ID:       farmId,
+				}
+			}
+			return err
+		}
+		if err := tx.Unscoped().Where("farm_id = ?", farmId).Delete(&entities.CropProduction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&entities.Farm{}, "id = ?", farmId).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, domain.AuditActionPurge, domain.AuditResourceFarm, farmId, mappers.ToDomainFarm(&purgedFarm), nil)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info(ctx, "Farm purged successfully")
 	return nil
 }
+
+type farmStatsRow struct {
+	FarmCount       int64
+	TotalLandArea   float64
+	AverageLandArea float64
+}
+
+type unitMeasureStatsRow struct {
+	UnitMeasure     string
+	FarmCount       int64
+	TotalLandArea   float64
+	AverageLandArea float64
+}
+
+type cropTypeStatsRow struct {
+	UnitMeasure         string
+	CropType            string
+	CropProductionCount int64
+	FarmCount           int64
+	IrrigatedCount      int64
+	InsuredCount        int64
+}
+
+// matchedFarmIDs builds a subquery selecting the ids of the organization farms matching the search filters
+func (f *FarmRepository) matchedFarmIDs(ctx context.Context, organizationID uuid.UUID, searchParameters *domain.FarmSearchParameters) *gorm.DB {
+	return f.filteredFarmsQuery(ctx, organizationID, searchParameters).Select("farms.id")
+}
+
+// GetFarmStats aggregates the farms matching the search filters, the overall land areas are computed in hectares
+func (f *FarmRepository) GetFarmStats(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*domain.FarmStats, error) {
+	f.logger.Info(ctx, "Aggregating farm statistics")
+	organizationID, err := domain.OrganizationIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var totals farmStatsRow
+	if err := f.db.WithContext(ctx).Unscoped().Model(&entities.Farm{}).
+		Select(`COUNT(*) AS farm_count, COALESCE(SUM(farms.land_area_hectares), 0) AS total_land_area,
+                COALESCE(AVG(farms.land_area_hectares), 0) AS average_land_area`).
+		Where("farms.id IN (?)", f.matchedFarmIDs(ctx, organizationID, searchParameters)).
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+
+	var unitMeasureRows []unitMeasureStatsRow
+	if err := f.db.WithContext(ctx).Unscoped().Model(&entities.Farm{}).
+		Select(`farms.unit_measure, COUNT(*) AS farm_count, SUM(farms.land_area) AS total_land_area,
+                AVG(farms.land_area) AS average_land_area`).
+		Where("farms.id IN (?)", f.matchedFarmIDs(ctx, organizationID, searchParameters)).
+		Group("farms.unit_measure").
+		Order("farms.unit_measure").
+		Scan(&unitMeasureRows).Error; err != nil {
+		return nil, err
+	}
+
+	// the crop production statistics cover every active crop production of the matched farms,
+	// just like the crop productions returned by the farms listing
+	var cropTypeRows []cropTypeStatsRow
+	if err := f.db.WithContext(ctx).Unscoped().Model(&entities.CropProduction{}).
+		Joins("JOIN farms ON farms.id = crop_productions.farm_id").
+		Select(`farms.unit_measure, crop_productions.crop_type, COUNT(*) AS crop_production_count,
+                COUNT(DISTINCT crop_productions.farm_id) AS farm_count,
+                SUM(CASE WHEN crop_productions.is_irrigated THEN 1 ELSE 0 END) AS irrigated_count,
+                SUM(CASE WHEN crop_productions.is_insured THEN 1 ELSE 0 END) AS insured_count`).
+		Where("crop_productions.farm_id IN (?)", f.matchedFarmIDs(ctx, organizationID, searchParameters)).
+		Where("(crop_productions.deleted_at IS NULL OR crop_productions.deleted_at = farms.deleted_at)").
+		Group("farms.unit_measure, crop_productions.crop_type").
+		Order("farms.unit_measure, crop_productions.crop_type").
+		Scan(&cropTypeRows).Error; err != nil {
+		return nil, err
+	}
+
+	f.logger.Info(ctx, "Farm statistics aggregated successfully")
+	return buildFarmStats(totals, unitMeasureRows, cropTypeRows), nil
+}
+
+// buildFarmStats combines the aggregation rows, the overall crop type statistics are the sum of the per unit ones
+func buildFarmStats(totals farmStatsRow, unitMeasureRows []unitMeasureStatsRow, cropTypeRows []cropTypeStatsRow) *domain.FarmStats {
+	stats := &domain.FarmStats{
+		FarmCount:       totals.FarmCount,
+		LandAreaUnit:    domain.UnitMeasureHectares,
+		TotalLandArea:   totals.TotalLandArea,
+		AverageLandArea: totals.AverageLandArea,
+		CropTypes:       []domain.CropTypeStats{},
+		UnitMeasures:    make([]domain.UnitMeasureStats, 0, len(unitMeasureRows)),
+	}
+
+	cropTypesByUnit := make(map[string][]cropTypeStatsRow)
+	overall := make(map[string]*cropTypeStatsRow)
+	for _, row := range cropTypeRows {
+		cropTypesByUnit[row.UnitMeasure] = append(cropTypesByUnit[row.UnitMeasure], row)
+		total, exists := overall[row.CropType]
+		if !exists {
+			total = &cropTypeStatsRow{CropType: row.CropType}
+			overall[row.CropType] = total
+		}
+		total.CropProductionCount += row.CropProductionCount
+		total.FarmCount += row.FarmCount
+		total.IrrigatedCount += row.IrrigatedCount
+		total.InsuredCount += row.InsuredCount
+	}
+	for _, total := range overall {
+		stats.CropTypes = append(stats.CropTypes, toCropTypeStats(*total))
+	}
+	sort.Slice(stats.CropTypes, func(i, j int) bool {
+		return stats.CropTypes[i].CropType < stats.CropTypes[j].CropType
+	})
+
+	for _, row := range unitMeasureRows {
+		unitStats := domain.UnitMeasureStats{
+			UnitMeasure:     domain.UnitMeasure(row.UnitMeasure),
+			FarmCount:       row.FarmCount,
+			TotalLandArea:   row.TotalLandArea,
+			AverageLandArea: row.AverageLandArea,
+			CropTypes:       make([]domain.CropTypeStats, 0, len(cropTypesByUnit[row.UnitMeasure])),
+		}
+		for _, cropTypeRow := range cropTypesByUnit[row.UnitMeasure] {
+			unitStats.CropTypes = append(unitStats.CropTypes, toCropTypeStats(cropTypeRow))
+		}
+		stats.UnitMeasures = append(stats.UnitMeasures, unitStats)
+	}
+	return stats
+}
+
+func toCropTypeStats(row cropTypeStatsRow) domain.CropTypeStats {
+	stats := domain.CropTypeStats{
+		CropType:            row.CropType,
+		CropProductionCount: row.CropProductionCount,
+		FarmCount:           row.FarmCount,
+	}
+	if row.CropProductionCount > 0 {
+		stats.IrrigatedRatio = float64(row.IrrigatedCount) / float64(row.CropProductionCount)
+		stats.InsuredRatio = float64(row.InsuredCount) / float64(row.CropProductionCount)
+	}
+	return stats
+}
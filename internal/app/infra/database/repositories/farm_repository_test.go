@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
@@ -25,8 +26,10 @@ type FarmRepositoryTestSuite struct {
 	DB   *gorm.DB
 	mock sqlmock.Sqlmock
 
-	repo *FarmRepository
-	farm *domain.Farm
+	repo           *FarmRepository
+	farm           *domain.Farm
+	organizationID uuid.UUID
+	ctx            context.Context
 }
 
 func (rs *FarmRepositoryTestSuite) SetupSuite() {
@@ -49,6 +52,8 @@ func (rs *FarmRepositoryTestSuite) SetupSuite() {
 	logger := logger.NewLogger()
 	rs.repo = NewFarmRepository(rs.DB, logger)
 	assert.IsType(rs.T(), &FarmRepository{}, rs.repo)
+	rs.organizationID = uuid.New()
+	rs.ctx = domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1", OrganizationID: rs.organizationID})
 	farmId := uuid.New()
 	coffeeCrop, err := domain.NewCropProduction(uuid.New(), farmId, domain.CropTypeCoffee, true, true)
 	if err != nil {
@@ -62,7 +67,7 @@ func (rs *FarmRepositoryTestSuite) SetupSuite() {
 		ID:          farmId,
 		Name:        "Test Farm",
 		LandArea:    100,
-		UnitMeasure: "acre",
+		UnitMeasure: domain.UnitMeasureAcres,
 		Address:     "Test Address",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -80,11 +85,13 @@ func (rs *FarmRepositoryTestSuite) AfterTest(_, _ string) {
 func (rs *FarmRepositoryTestSuite) TestCreateFarm() {
 	rs.mock.ExpectBegin()
 	rs.mock.ExpectExec(
-		regexp.QuoteMeta(`INSERT INTO "farms" ("id","name","land_area","unit_measure","address","created_at","updated_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).
+		regexp.QuoteMeta(`INSERT INTO "farms" ("id","organization_id","name","land_area","land_area_hectares","unit_measure","address","created_at","updated_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`)).
 		WithArgs(
 			rs.farm.ID,
+			rs.organizationID,
 			rs.farm.Name,
 			rs.farm.LandArea,
+			domain.UnitMeasureAcres.ToHectares(rs.farm.LandArea),
 			rs.farm.UnitMeasure,
 			rs.farm.Address,
 			testutils.AnyTime{},
@@ -92,12 +99,14 @@ func (rs *FarmRepositoryTestSuite) TestCreateFarm() {
 			nil,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 	rs.mock.ExpectExec(`INSERT INTO "crop_productions"`).WillReturnResult(sqlmock.NewResult(2, 2))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionCreate, domain.AuditResourceFarm, rs.farm.ID.String())
 	rs.mock.ExpectCommit()
 
-	farm, err := rs.repo.CreateFarm(context.Background(), rs.farm)
+	farm, err := rs.repo.CreateFarm(rs.ctx, rs.farm)
 	assert.NoError(rs.T(), err)
 	assert.NotNil(rs.T(), farm.ID)
 	assert.Equal(rs.T(), rs.farm.ID, farm.ID)
+	assert.Equal(rs.T(), rs.organizationID, farm.OrganizationID)
 	assert.Equal(rs.T(), rs.farm.Name, farm.Name)
 	assert.Equal(rs.T(), rs.farm.LandArea, farm.LandArea)
 	assert.Equal(rs.T(), rs.farm.UnitMeasure, farm.UnitMeasure)
@@ -110,6 +119,36 @@ func (rs *FarmRepositoryTestSuite) TestCreateFarm() {
 
 }
 
+func (rs *FarmRepositoryTestSuite) TestCreateFarms() {
+	otherFarm := testutils.GenerateFarms(1, nil, nil)[0]
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "farms" ("id","organization_id","name","land_area","land_area_hectares","unit_measure","address","created_at","updated_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10),($11,$12,$13,$14,$15,$16,$17,$18,$19,$20)`)).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	rs.mock.ExpectExec(`INSERT INTO "crop_productions"`).WillReturnResult(sqlmock.NewResult(2, 2))
+	// the audit events of an import are written in batches as well
+	rs.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_events" ("id","organization_id","actor_id","actor_type","action","resource_type","resource_id","request_id","before","after","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11),($12,`)).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	rs.mock.ExpectCommit()
+
+	farms, err := rs.repo.CreateFarms(rs.ctx, []*domain.Farm{rs.farm, otherFarm})
+	assert.NoError(rs.T(), err)
+	assert.Len(rs.T(), farms, 2)
+	assert.Equal(rs.T(), rs.farm.ID, farms[0].ID)
+	assert.Equal(rs.T(), otherFarm.ID, farms[1].ID)
+	assert.Equal(rs.T(), rs.organizationID, farms[1].OrganizationID)
+	assert.False(rs.T(), farms[0].CreatedAt.IsZero())
+}
+
+func (rs *FarmRepositoryTestSuite) TestCreateFarmsRollsBackOnError() {
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "farms"`)).WillReturnError(errors.New("insert failed"))
+	rs.mock.ExpectRollback()
+
+	farms, err := rs.repo.CreateFarms(rs.ctx, []*domain.Farm{rs.farm})
+	assert.Error(rs.T(), err)
+	assert.Nil(rs.T(), farms)
+}
+
 func (rs *FarmRepositoryTestSuite) TestListFarmsWithFilters() {
 	perPage := 10
 	minimumLandArea := 100.5
@@ -124,8 +163,8 @@ func (rs *FarmRepositoryTestSuite) TestListFarmsWithFilters() {
 	)
 
 	// this test asserts that the filters are properly used by the repository when listing the farms
-	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT farms.id`)).
-		WithArgs(domain.CropTypeCoffee, minimumLandArea, maximumLandArea, perPage).
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id FROM "farms"`)).
+		WithArgs(rs.organizationID, domain.CropTypeCoffee, minimumLandArea, maximumLandArea, perPage).
 		WillReturnRows(farmIdsRows)
 
 	rows := sqlmock.NewRows([]string{
@@ -137,17 +176,17 @@ func (rs *FarmRepositoryTestSuite) TestListFarmsWithFilters() {
 	)
 
 	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT`)).
-		WithArgs(rs.farm.ID.String()).
+		WithArgs(rs.organizationID, rs.farm.ID.String()).
 		WillReturnRows(rows)
 
 	searchParams := &domain.FarmSearchParameters{
 		Page:            1,
 		PerPage:         perPage,
-		CropType:        testutils.PointerTo(domain.CropTypeCoffee.String()),
+		CropTypes:       []domain.CropType{domain.CropTypeCoffee},
 		MinimumLandArea: &minimumLandArea,
 		MaximumLandArea: &maximumLandArea,
 	}
-	response, err := rs.repo.ListFarms(context.Background(), searchParams)
+	response, err := rs.repo.ListFarms(rs.ctx, searchParams)
 
 	// Assertions
 	assert.NoError(rs.T(), err)
@@ -156,20 +195,363 @@ func (rs *FarmRepositoryTestSuite) TestListFarmsWithFilters() {
 	assert.Equal(rs.T(), rs.farm.ID, response.Items[0].ID)
 }
 
+func (rs *FarmRepositoryTestSuite) TestListFarmsWithoutCropProductions() {
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(DISTINCT("farms"."id")) FROM "farms" LEFT JOIN crop_productions`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id FROM "farms" LEFT JOIN crop_productions`)).
+		WithArgs(rs.organizationID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}).AddRow(rs.farm.ID))
+
+	// the LEFT JOIN returns NULL crop production columns for farms without crop productions
+	rows := sqlmock.NewRows([]string{
+		"farm_id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at",
+		"crop_production_id", "crop_production_farm_id", "crop_type", "is_irrigated", "is_insured",
+	}).AddRow(
+		rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil,
+		nil, nil, nil, nil, nil,
+	)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id AS farm_id`)).
+		WithArgs(rs.organizationID, rs.farm.ID.String()).
+		WillReturnRows(rows)
+
+	response, err := rs.repo.ListFarms(rs.ctx, &domain.FarmSearchParameters{Page: 1, PerPage: 10})
+
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), int64(1), response.TotalCount)
+	assert.Equal(rs.T(), 1, len(response.Items))
+	assert.Equal(rs.T(), rs.farm.ID, response.Items[0].ID)
+	assert.NotNil(rs.T(), response.Items[0].CropProductions)
+	assert.Empty(rs.T(), response.Items[0].CropProductions)
+}
+
+func (rs *FarmRepositoryTestSuite) TestListFarmsWithRicherFilters() {
+	name := "50%_off"
+	createdAfter := time.Now().Add(-time.Hour)
+	cropTypes := []domain.CropType{domain.CropTypeCoffee, domain.CropTypeRice}
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(DISTINCT("farms"."id"))`)).
+		WithArgs(rs.organizationID, `%50\%\_off%`, createdAfter, true, domain.CropTypeCoffee, domain.CropTypeRice,
+			domain.CropTypeCoffee, domain.CropTypeRice, true, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	// this test asserts that the all-of crop type match counts the distinct crop types of each farm
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`farms.organization_id = $1 AND farms.name ILIKE $2 AND farms.created_at >= $3 AND crop_productions.is_irrigated = $4 AND crop_productions.crop_type IN ($5,$6) AND (SELECT COUNT(DISTINCT matched_crop_productions.crop_type) FROM crop_productions AS matched_crop_productions WHERE`)).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}))
+
+	response, err := rs.repo.ListFarms(rs.ctx, &domain.FarmSearchParameters{
+		Name:          &name,
+		CreatedAfter:  &createdAfter,
+		IsIrrigated:   testutils.PointerTo(true),
+		CropTypes:     cropTypes,
+		CropTypeMatch: domain.CropTypeMatchAll,
+		Page:          1,
+		PerPage:       10,
+	})
+
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), int64(0), response.TotalCount)
+	assert.Empty(rs.T(), response.Items)
+}
+
+func (rs *FarmRepositoryTestSuite) TestListFarmsCreatedBeforeIsExclusive() {
+	createdBefore := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`farms.organization_id = $1 AND farms.created_at < $2`)).
+		WithArgs(rs.organizationID, createdBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`farms.organization_id = $1 AND farms.created_at < $2`)).
+		WithArgs(rs.organizationID, createdBefore, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}))
+
+	response, err := rs.repo.ListFarms(rs.ctx, &domain.FarmSearchParameters{
+		CreatedBefore: &createdBefore,
+		Page:          1,
+		PerPage:       10,
+	})
+
+	assert.NoError(rs.T(), err)
+	assert.Empty(rs.T(), response.Items)
+	assert.NoError(rs.T(), rs.mock.ExpectationsWereMet())
+}
+
+func (rs *FarmRepositoryTestSuite) TestListFarmsWithSort() {
+	secondFarmID := uuid.New()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(DISTINCT("farms"."id"))`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	// this test asserts that the requested sort is applied with the farm id as a tie-breaker
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY "farms"."id" ORDER BY "farms"."land_area_hectares" DESC,"farms"."name","farms"."id"`)).
+		WithArgs(rs.organizationID, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_id"}).AddRow(secondFarmID).AddRow(rs.farm.ID))
+
+	rows := sqlmock.NewRows([]string{
+		"farm_id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at",
+		"crop_production_id", "crop_production_farm_id", "crop_type", "is_irrigated", "is_insured",
+	}).AddRow(
+		rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil,
+		rs.farm.CropProductions[0].ID, rs.farm.ID, rs.farm.CropProductions[0].CropType, rs.farm.CropProductions[0].IsIrrigated, rs.farm.CropProductions[0].IsInsured,
+	).AddRow(
+		secondFarmID, rs.farm.Name, rs.farm.LandArea*2, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil,
+		uuid.New(), secondFarmID, domain.CropTypeCorn, false, false,
+	)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id AS farm_id`)).
+		WithArgs(rs.organizationID, secondFarmID.String(), rs.farm.ID.String()).
+		WillReturnRows(rows)
+
+	response, err := rs.repo.ListFarms(rs.ctx, &domain.FarmSearchParameters{
+		Page:    1,
+		PerPage: 10,
+		Sort: []domain.FarmSort{
+			{Field: domain.FarmSortByLandArea, Descending: true},
+			{Field: domain.FarmSortByName},
+		},
+	})
+
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), 2, len(response.Items))
+	assert.Equal(rs.T(), secondFarmID, response.Items[0].ID)
+	assert.Equal(rs.T(), rs.farm.ID, response.Items[1].ID)
+}
+
+func (rs *FarmRepositoryTestSuite) TestGetFarmStats() {
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`COALESCE(SUM(farms.land_area_hectares), 0) AS total_land_area`)).
+		WithArgs(rs.organizationID, domain.CropTypeCorn).
+		WillReturnRows(sqlmock.NewRows([]string{"farm_count", "total_land_area", "average_land_area"}).AddRow(3, 300.0, 100.0))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY "farms"."unit_measure" ORDER BY farms.unit_measure`)).
+		WithArgs(rs.organizationID, domain.CropTypeCorn).
+		WillReturnRows(sqlmock.NewRows([]string{"unit_measure", "farm_count", "total_land_area", "average_land_area"}).
+			AddRow(domain.UnitMeasureAcres, 1, 247.1, 247.1).
+			AddRow(domain.UnitMeasureHectares, 2, 200.0, 100.0))
+	// the crop production statistics only consider the crop productions of the matched farms
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`WHERE crop_productions.farm_id IN (SELECT farms.id FROM "farms" LEFT JOIN crop_productions`)).
+		WithArgs(rs.organizationID, domain.CropTypeCorn).
+		WillReturnRows(sqlmock.NewRows([]string{"unit_measure", "crop_type", "crop_production_count", "farm_count", "irrigated_count", "insured_count"}).
+			AddRow(domain.UnitMeasureAcres, domain.CropTypeCorn, 1, 1, 1, 0).
+			AddRow(domain.UnitMeasureHectares, domain.CropTypeCoffee, 1, 1, 0, 0).
+			AddRow(domain.UnitMeasureHectares, domain.CropTypeCorn, 3, 2, 1, 3))
+
+	stats, err := rs.repo.GetFarmStats(rs.ctx, &domain.FarmSearchParameters{
+		CropTypes: []domain.CropType{domain.CropTypeCorn},
+	})
+
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), int64(3), stats.FarmCount)
+	assert.Equal(rs.T(), domain.UnitMeasureHectares, stats.LandAreaUnit)
+	assert.Equal(rs.T(), 300.0, stats.TotalLandArea)
+	assert.Equal(rs.T(), 100.0, stats.AverageLandArea)
+	assert.Equal(rs.T(), []domain.CropTypeStats{
+		{CropType: domain.CropTypeCoffee.String(), CropProductionCount: 1, FarmCount: 1},
+		{CropType: domain.CropTypeCorn.String(), CropProductionCount: 4, FarmCount: 3, IrrigatedRatio: 0.5, InsuredRatio: 0.75},
+	}, stats.CropTypes)
+	assert.Len(rs.T(), stats.UnitMeasures, 2)
+	assert.Equal(rs.T(), domain.UnitMeasureAcres, stats.UnitMeasures[0].UnitMeasure)
+	assert.Equal(rs.T(), []domain.CropTypeStats{
+		{CropType: domain.CropTypeCorn.String(), CropProductionCount: 1, FarmCount: 1, IrrigatedRatio: 1},
+	}, stats.UnitMeasures[0].CropTypes)
+	assert.Equal(rs.T(), int64(2), stats.UnitMeasures[1].FarmCount)
+	assert.Len(rs.T(), stats.UnitMeasures[1].CropTypes, 2)
+}
+
+func (rs *FarmRepositoryTestSuite) TestListFarmsByCursor() {
+	cursor := &domain.FarmCursor{CreatedAt: time.Now().Add(-time.Hour), ID: uuid.New()}
+	secondFarmID := uuid.New()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id, farms.created_at FROM "farms" LEFT JOIN crop_productions`)).
+		WithArgs(rs.organizationID, domain.CropTypeCoffee, cursor.CreatedAt, cursor.ID, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
+			AddRow(rs.farm.ID, rs.farm.CreatedAt).
+			AddRow(secondFarmID, rs.farm.CreatedAt))
+
+	rows := sqlmock.NewRows([]string{
+		"farm_id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at",
+		"crop_production_id", "crop_production_farm_id", "crop_type", "is_irrigated", "is_insured",
+	}).AddRow(
+		rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil,
+		rs.farm.CropProductions[0].ID, rs.farm.ID, rs.farm.CropProductions[0].CropType, rs.farm.CropProductions[0].IsIrrigated, rs.farm.CropProductions[0].IsInsured,
+	)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT farms.id AS farm_id`)).
+		WithArgs(rs.organizationID, rs.farm.ID.String()).
+		WillReturnRows(rows)
+
+	response, err := rs.repo.ListFarmsByCursor(rs.ctx, &domain.FarmSearchParameters{
+		CropTypes: []domain.CropType{domain.CropTypeCoffee},
+		Cursor:    cursor,
+		Limit:     1,
+	})
+	assert.NoError(rs.T(), err)
+	assert.Len(rs.T(), response.Items, 1)
+	assert.Equal(rs.T(), rs.farm.ID, response.Items[0].ID)
+	if assert.NotNil(rs.T(), response.NextCursor) {
+		nextCursor, err := domain.DecodeFarmCursor(*response.NextCursor)
+		assert.NoError(rs.T(), err)
+		assert.Equal(rs.T(), rs.farm.ID, nextCursor.ID)
+	}
+}
+
+func (rs *FarmRepositoryTestSuite) TestGetFarmByID() {
+	farmRows := sqlmock.NewRows([]string{
+		"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at",
+	}).AddRow(
+		rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil,
+	)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2) AND "farms"."deleted_at" IS NULL`)).
+		WithArgs(rs.farm.ID.String(), rs.organizationID, 1).
+		WillReturnRows(farmRows)
+
+	cropRows := sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"})
+	for _, crop := range rs.farm.CropProductions {
+		cropRows.AddRow(crop.ID, crop.FarmID, crop.CropType, crop.IsIrrigated, crop.IsInsured)
+	}
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(cropRows)
+
+	farm, err := rs.repo.GetFarmByID(rs.ctx, rs.farm.ID.String())
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), rs.farm.ID, farm.ID)
+	assert.Equal(rs.T(), rs.farm.Name, farm.Name)
+	assert.Len(rs.T(), farm.CropProductions, len(rs.farm.CropProductions))
+}
+
+func (rs *FarmRepositoryTestSuite) TestGetNonExistingFarm() {
+	farmId := uuid.New().String()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms"`)).
+		WithArgs(farmId, rs.organizationID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	farm, err := rs.repo.GetFarmByID(rs.ctx, farmId)
+	expectedErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farmId,
+	}
+	assert.Nil(rs.T(), farm)
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
+func (rs *FarmRepositoryTestSuite) TestUpdateFarmReconcilesCropProductions() {
+	createdAt := time.Now().Add(-24 * time.Hour)
+	coffeeCrop := rs.farm.CropProductions[0]
+	riceCrop := rs.farm.CropProductions[1]
+
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2)`)).
+		WithArgs(rs.farm.ID, rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at"}).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, createdAt, createdAt, nil))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(coffeeCrop.ID, rs.farm.ID, coffeeCrop.CropType, coffeeCrop.IsIrrigated, coffeeCrop.IsInsured).
+			AddRow(riceCrop.ID, rs.farm.ID, riceCrop.CropType, riceCrop.IsIrrigated, riceCrop.IsInsured))
+	// the canonical hectare value is kept in sync with the land area
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "address"=$1,"land_area"=$2,"land_area_hectares"=$3,"name"=$4,"unit_measure"=$5,"updated_at"=$6 WHERE id = $7`)).
+		WithArgs(rs.farm.Address, rs.farm.LandArea, domain.UnitMeasureAcres.ToHectares(rs.farm.LandArea), "Updated Farm", rs.farm.UnitMeasure, testutils.AnyTime{}, rs.farm.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "crop_type"=$1,"is_insured"=$2,"is_irrigated"=$3,"updated_at"=$4 WHERE id = $5`)).
+		WithArgs(coffeeCrop.CropType, false, false, testutils.AnyTime{}, coffeeCrop.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "crop_productions"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "deleted_at"=$1 WHERE id IN ($2)`)).
+		WithArgs(testutils.AnyTime{}, riceCrop.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionUpdate, domain.AuditResourceFarm, rs.farm.ID.String())
+	rs.mock.ExpectCommit()
+
+	updatedFarm := *rs.farm
+	updatedFarm.Name = "Updated Farm"
+	updatedFarm.CropProductions = []domain.CropProduction{
+		{ID: coffeeCrop.ID, CropType: coffeeCrop.CropType, IsIrrigated: false, IsInsured: false},
+		{CropType: domain.CropTypeCorn.String(), IsIrrigated: true},
+	}
+	farm, err := rs.repo.UpdateFarm(rs.ctx, &updatedFarm)
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), "Updated Farm", farm.Name)
+	assert.WithinDuration(rs.T(), createdAt, farm.CreatedAt, time.Second)
+	assert.True(rs.T(), farm.UpdatedAt.After(createdAt))
+	assert.Len(rs.T(), farm.CropProductions, 2)
+	assert.NotEqual(rs.T(), uuid.Nil, farm.CropProductions[1].ID)
+	assert.Equal(rs.T(), rs.farm.ID, farm.CropProductions[1].FarmID)
+}
+
+func (rs *FarmRepositoryTestSuite) TestPatchFarmLocksTheStoredFarm() {
+	createdAt := time.Now().Add(-24 * time.Hour)
+	coffeeCrop := rs.farm.CropProductions[0]
+	riceCrop := rs.farm.CropProductions[1]
+
+	rs.mock.ExpectBegin()
+	// the farm stays locked until the patched farm is stored, so concurrent patches are applied one after the other
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2) AND "farms"."deleted_at" IS NULL ORDER BY "farms"."id" LIMIT $3 FOR UPDATE`)).
+		WithArgs(rs.farm.ID.String(), rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at"}).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, createdAt, createdAt, nil))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(coffeeCrop.ID, rs.farm.ID, coffeeCrop.CropType, coffeeCrop.IsIrrigated, coffeeCrop.IsInsured).
+			AddRow(riceCrop.ID, rs.farm.ID, riceCrop.CropType, riceCrop.IsIrrigated, riceCrop.IsInsured))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "address"=$1,"land_area"=$2,"land_area_hectares"=$3,"name"=$4,"unit_measure"=$5,"updated_at"=$6 WHERE id = $7`)).
+		WithArgs(rs.farm.Address, rs.farm.LandArea, domain.UnitMeasureAcres.ToHectares(rs.farm.LandArea), "Patched Farm", rs.farm.UnitMeasure, testutils.AnyTime{}, rs.farm.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, crop := range []domain.CropProduction{coffeeCrop, riceCrop} {
+		rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "crop_type"=$1,"is_insured"=$2,"is_irrigated"=$3,"updated_at"=$4 WHERE id = $5`)).
+			WithArgs(crop.CropType, crop.IsInsured, crop.IsIrrigated, testutils.AnyTime{}, crop.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionUpdate, domain.AuditResourceFarm, rs.farm.ID.String())
+	rs.mock.ExpectCommit()
+
+	farm, err := rs.repo.PatchFarm(rs.ctx, rs.farm.ID.String(), domain.FarmPatch{Name: testutils.PointerTo("Patched Farm")})
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), "Patched Farm", farm.Name)
+	assert.Equal(rs.T(), rs.farm.Address, farm.Address)
+	assert.Len(rs.T(), farm.CropProductions, 2)
+	assert.WithinDuration(rs.T(), createdAt, farm.CreatedAt, time.Second)
+	assert.NoError(rs.T(), rs.mock.ExpectationsWereMet())
+}
+
+func (rs *FarmRepositoryTestSuite) TestUpdateNonExistingFarm() {
+	farm := *rs.farm
+	farm.ID = uuid.New()
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2)`)).
+		WithArgs(farm.ID, rs.organizationID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	rs.mock.ExpectRollback()
+
+	result, err := rs.repo.UpdateFarm(rs.ctx, &farm)
+	expectedErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farm.ID.String(),
+	}
+	assert.Nil(rs.T(), result)
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
 func (rs *FarmRepositoryTestSuite) TestSuccessfulFarmDeletion() {
 	rs.mock.ExpectBegin()
-	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE`)).WithArgs(testutils.AnyTime{}, rs.farm.ID.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2) AND "farms"."deleted_at" IS NULL`)).
+		WithArgs(rs.farm.ID.String(), rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at"}).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1 AND "crop_productions"."deleted_at" IS NULL`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "deleted_at"=$1,"updated_at"=$2 WHERE id = $3 AND "farms"."deleted_at" IS NULL`)).
+		WithArgs(testutils.AnyTime{}, testutils.AnyTime{}, rs.farm.ID.String()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "deleted_at"=$1,"updated_at"=$2 WHERE farm_id = $3 AND "crop_productions"."deleted_at" IS NULL`)).
+		WithArgs(testutils.AnyTime{}, testutils.AnyTime{}, rs.farm.ID.String()).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionDelete, domain.AuditResourceFarm, rs.farm.ID.String())
 	rs.mock.ExpectCommit()
-	err := rs.repo.DeleteFarm(context.Background(), rs.farm.ID.String())
+	err := rs.repo.DeleteFarm(rs.ctx, rs.farm.ID.String())
 	assert.NoError(rs.T(), err)
 }
 
 func (rs *FarmRepositoryTestSuite) TestDeleteNonExistingFarm() {
 	invalidId := "invalid_id"
 	rs.mock.ExpectBegin()
-	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE`)).WithArgs(testutils.AnyTime{}, invalidId).WillReturnResult(sqlmock.NewResult(0, 0))
-	rs.mock.ExpectCommit()
-	err := rs.repo.DeleteFarm(context.Background(), invalidId)
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms"`)).WithArgs(invalidId, rs.organizationID, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rs.mock.ExpectRollback()
+	err := rs.repo.DeleteFarm(rs.ctx, invalidId)
 	expectedErr := shared.NotFoundError{
 		Resource: "Farm",
 		ID:       invalidId,
@@ -177,6 +559,163 @@ func (rs *FarmRepositoryTestSuite) TestDeleteNonExistingFarm() {
 	assert.EqualError(rs.T(), err, expectedErr.Error())
 }
 
+func (rs *FarmRepositoryTestSuite) TestRestoreFarm() {
+	farmId := rs.farm.ID.String()
+	deletedAt := time.Now().Add(-time.Hour)
+	farmColumns := []string{"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at"}
+
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE deleted_at IS NOT NULL AND (id = $1 AND organization_id = $2)`)).
+		WithArgs(farmId, rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows(farmColumns).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, deletedAt))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "crop_productions" SET "deleted_at"=$1,"updated_at"=$2 WHERE farm_id = $3 AND deleted_at = $4`)).
+		WithArgs(nil, testutils.AnyTime{}, farmId, deletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "deleted_at"=$1,"updated_at"=$2 WHERE id = $3`)).
+		WithArgs(nil, testutils.AnyTime{}, farmId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE id = $1 AND "farms"."deleted_at" IS NULL`)).
+		WillReturnRows(sqlmock.NewRows(farmColumns).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, nil))
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1 AND "crop_productions"."deleted_at" IS NULL`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured"}).
+			AddRow(rs.farm.CropProductions[0].ID, rs.farm.ID, rs.farm.CropProductions[0].CropType, true, true))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionRestore, domain.AuditResourceFarm, farmId)
+	rs.mock.ExpectCommit()
+
+	farm, err := rs.repo.RestoreFarm(rs.ctx, farmId)
+	assert.NoError(rs.T(), err)
+	assert.Equal(rs.T(), rs.farm.ID, farm.ID)
+	assert.Nil(rs.T(), farm.DeletedAt)
+	assert.Len(rs.T(), farm.CropProductions, 1)
+}
+
+func (rs *FarmRepositoryTestSuite) TestRestoreNonDeletedFarm() {
+	farmId := rs.farm.ID.String()
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE deleted_at IS NOT NULL AND (id = $1 AND organization_id = $2)`)).
+		WithArgs(farmId, rs.organizationID, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	rs.mock.ExpectRollback()
+
+	farm, err := rs.repo.RestoreFarm(rs.ctx, farmId)
+	expectedErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farmId,
+	}
+	assert.Nil(rs.T(), farm)
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
+func (rs *FarmRepositoryTestSuite) TestPurgeFarm() {
+	farmId := rs.farm.ID.String()
+	deletedAt := time.Now().Add(-time.Hour)
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE id = $1 AND organization_id = $2 ORDER BY "farms"."id" LIMIT $3`)).
+		WithArgs(farmId, rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "land_area", "unit_measure", "address", "created_at", "updated_at", "deleted_at"}).
+			AddRow(rs.farm.ID, rs.farm.Name, rs.farm.LandArea, rs.farm.UnitMeasure, rs.farm.Address, rs.farm.CreatedAt, rs.farm.UpdatedAt, deletedAt))
+	// the crop productions deleted before the farm are purged, and recorded, with it
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "crop_productions" WHERE "crop_productions"."farm_id" = $1`)).
+		WithArgs(rs.farm.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "farm_id", "crop_type", "is_irrigated", "is_insured", "deleted_at"}).
+			AddRow(rs.farm.CropProductions[0].ID, rs.farm.ID, rs.farm.CropProductions[0].CropType, true, true, deletedAt))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "crop_productions" WHERE farm_id = $1`)).
+		WithArgs(farmId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	rs.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "farms" WHERE id = $1`)).
+		WithArgs(farmId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectAuditEvent(rs.mock, rs.organizationID, domain.AuditActionPurge, domain.AuditResourceFarm, farmId)
+	rs.mock.ExpectCommit()
+
+	err := rs.repo.PurgeFarm(rs.ctx, farmId)
+	assert.NoError(rs.T(), err)
+}
+
+func (rs *FarmRepositoryTestSuite) TestPurgeNonExistingFarm() {
+	farmId := uuid.New().String()
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms"`)).
+		WithArgs(farmId, rs.organizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rs.mock.ExpectRollback()
+
+	err := rs.repo.PurgeFarm(rs.ctx, farmId)
+	expectedErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farmId,
+	}
+	assert.EqualError(rs.T(), err, expectedErr.Error())
+}
+
+func (rs *FarmRepositoryTestSuite) TestFarmOfAnotherOrganization() {
+	otherOrganizationID := uuid.New()
+	otherCtx := domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-2", OrganizationID: otherOrganizationID})
+	farmId := rs.farm.ID.String()
+	notFoundErr := shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farmId,
+	}
+
+	// the farm queries are filtered by the caller organization, so the farms of other organizations are never matched
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2)`)).
+		WithArgs(farmId, otherOrganizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	farm, err := rs.repo.GetFarmByID(otherCtx, farmId)
+	assert.Nil(rs.T(), farm)
+	assert.EqualError(rs.T(), err, notFoundErr.Error())
+
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE (id = $1 AND organization_id = $2)`)).
+		WithArgs(farmId, otherOrganizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rs.mock.ExpectRollback()
+	err = rs.repo.DeleteFarm(otherCtx, farmId)
+	assert.EqualError(rs.T(), err, notFoundErr.Error())
+
+	rs.mock.ExpectBegin()
+	rs.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE id = $1 AND organization_id = $2`)).
+		WithArgs(farmId, otherOrganizationID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	rs.mock.ExpectRollback()
+	err = rs.repo.PurgeFarm(otherCtx, farmId)
+	assert.EqualError(rs.T(), err, notFoundErr.Error())
+}
+
+func (rs *FarmRepositoryTestSuite) TestFarmsRequireAnOrganization() {
+	contexts := map[string]context.Context{
+		"anonymous":                 context.Background(),
+		"principal without tenancy": domain.ContextWithPrincipal(context.Background(), &domain.Principal{ID: "user-1"}),
+	}
+	farmId := rs.farm.ID.String()
+	for name, ctx := range contexts {
+		rs.Run(name, func() {
+			// no query is sent to the database without an organization
+			_, err := rs.repo.CreateFarm(ctx, rs.farm)
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.CreateFarms(ctx, []*domain.Farm{rs.farm})
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.GetFarmByID(ctx, farmId)
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.ListFarms(ctx, &domain.FarmSearchParameters{Page: 1, PerPage: 10})
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.ListFarmsByCursor(ctx, &domain.FarmSearchParameters{Limit: 10})
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.GetFarmStats(ctx, &domain.FarmSearchParameters{})
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			_, err = rs.repo.UpdateFarm(ctx, rs.farm)
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			assert.ErrorIs(rs.T(), rs.repo.DeleteFarm(ctx, farmId), domain.ErrMissingOrganization)
+			_, err = rs.repo.RestoreFarm(ctx, farmId)
+			assert.ErrorIs(rs.T(), err, domain.ErrMissingOrganization)
+			assert.ErrorIs(rs.T(), rs.repo.PurgeFarm(ctx, farmId), domain.ErrMissingOrganization)
+		})
+	}
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(FarmRepositoryTestSuite))
 }
@@ -11,5 +11,17 @@ var Module = fx.Options(
 			NewFarmRepository,
 			fx.As(new(domain.FarmRepository)),
 		),
+		fx.Annotate(
+			NewCropProductionRepository,
+			fx.As(new(domain.CropProductionRepository)),
+		),
+		fx.Annotate(
+			NewAPIKeyRepository,
+			fx.As(new(domain.APIKeyRepository)),
+		),
+		fx.Annotate(
+			NewAuditEventRepository,
+			fx.As(new(domain.AuditEventRepository)),
+		),
 	),
 )
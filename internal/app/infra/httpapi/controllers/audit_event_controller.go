@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain/usecases"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditEventController struct {
+	listAuditEventsUseCase usecases.ListAuditEventsUseCase
+	logger                 *logger.Logger
+}
+
+// parseAuditEventSearchParameters reads the audit event filters from the query string,
+// the returned error message is meant to be sent back to the client
+func parseAuditEventSearchParameters(c *fiber.Ctx) (*domain.AuditEventSearchParameters, error) {
+	queries := c.Queries()
+	searchParameters := &domain.AuditEventSearchParameters{
+		Page:    c.QueryInt("page", 1),
+		PerPage: c.QueryInt("per_page", 10),
+	}
+	var err error
+
+	if rawResourceType, exists := queries["resource_type"]; exists {
+		resourceType := domain.AuditResourceType(rawResourceType)
+		if !resourceType.IsValid() {
+			return nil, fmt.Errorf(`Query parameter "resource_type" must be either "%s" or "%s"`, domain.AuditResourceFarm, domain.AuditResourceCropProduction)
+		}
+		searchParameters.ResourceType = &resourceType
+	}
+	if resourceID, exists := queries["resource_id"]; exists {
+		searchParameters.ResourceID = &resourceID
+	}
+	if actorID, exists := queries["actor_id"]; exists {
+		searchParameters.ActorID = &actorID
+	}
+	if searchParameters.From, err = parseTimeQuery(queries, "from"); err != nil {
+		return nil, err
+	}
+	if searchParameters.To, err = parseTimeUpperBoundQuery(queries, "to"); err != nil {
+		return nil, err
+	}
+	if searchParameters.From != nil && searchParameters.To != nil && searchParameters.From.After(*searchParameters.To) {
+		return nil, fmt.Errorf(`Query parameter "from" must not be after "to"`)
+	}
+	return searchParameters, nil
+}
+
+// @Summary List audit events
+// @Description Get the audit trail of the farm and crop production mutations of the caller organization, newest first
+// @Tags AuditEvent
+// @Accept json
+// @Produce json
+// @Param resource_type query string false "Type of the mutated resource" Enums(farm, crop_production)
+// @Param resource_id query string false "ID of the mutated resource"
+// @Param actor_id query string false "ID of the user or API key that made the mutation"
+// @Param from query string false "Only events recorded at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param to query string false "Only events recorded before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date"
+// @Param page query int false "Page" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {array} domain.AuditEvent "List of Audit Events"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /audit-events [get]
+func (ac *AuditEventController) ListAuditEvents(c *fiber.Ctx) error {
+	searchParameters, err := parseAuditEventSearchParameters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	auditEvents, err := ac.listAuditEventsUseCase.Execute(requestContext(c), searchParameters)
+	if err != nil {
+		var forbiddenError *shared.ForbiddenError
+		if errors.As(err, &forbiddenError) || errors.Is(err, domain.ErrMissingOrganization) {
+			return c.Status(fiber.StatusForbidden).JSON(shared.CustomError{
+				Error: err.Error(),
+			})
+		}
+		ac.logger.Error(requestContext(c), "Unexpected error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
+			Error: "Internal server error",
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(auditEvents)
+}
+
+func NewAuditEventController(
+	listAuditEventsUseCase usecases.ListAuditEventsUseCase,
+	logger *logger.Logger,
+) *AuditEventController {
+	return &AuditEventController{
+		listAuditEventsUseCase: listAuditEventsUseCase,
+		logger:                 logger,
+	}
+}
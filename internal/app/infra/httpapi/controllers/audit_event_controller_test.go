@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockListAuditEventsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListAuditEventsUseCase) Execute(ctx context.Context, searchParameters *domain.AuditEventSearchParameters) (*models.PaginatedResponse[*domain.AuditEvent], error) {
+	args := m.Called(ctx, searchParameters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PaginatedResponse[*domain.AuditEvent]), args.Error(1)
+}
+
+type AuditEventControllerTestSuite struct {
+	suite.Suite
+	logger *logger.Logger
+}
+
+func (as *AuditEventControllerTestSuite) SetupSuite() {
+	as.logger = logger.NewLogger()
+}
+
+func (as *AuditEventControllerTestSuite) TestListAuditEvents() {
+	resourceID := uuid.NewString()
+	page := &models.PaginatedResponse[*domain.AuditEvent]{
+		Items:       []*domain.AuditEvent{{ID: uuid.New(), Action: domain.AuditActionDelete, ResourceType: domain.AuditResourceFarm, ResourceID: resourceID}},
+		TotalCount:  1,
+		CurrentPage: 2,
+		PerPage:     5,
+	}
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedParameters func(*domain.AuditEventSearchParameters) bool
+		mockResponse       *models.PaginatedResponse[*domain.AuditEvent]
+		mockError          error
+	}{
+		{
+			name:               "Successful audit events retrieval",
+			query:              "?resource_type=farm&resource_id=" + resourceID + "&actor_id=user-1&from=2024-01-01&to=2024-02-01T00:00:00Z&page=2&per_page=5",
+			expectedStatusCode: fiber.StatusOK,
+			expectedParameters: func(p *domain.AuditEventSearchParameters) bool {
+				return *p.ResourceType == domain.AuditResourceFarm && *p.ResourceID == resourceID && *p.ActorID == "user-1" &&
+					p.From.Format("2006-01-02") == "2024-01-01" && p.To.Month() == 2 && p.Page == 2 && p.PerPage == 5
+			},
+			mockResponse: page,
+		},
+		{
+			name:               "Date-only to includes the whole day",
+			query:              "?to=2024-05-10",
+			expectedStatusCode: fiber.StatusOK,
+			expectedParameters: func(p *domain.AuditEventSearchParameters) bool {
+				// an event recorded in the afternoon of the to date is matched, the bound is the start of the next day
+				return p.To.Equal(time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC)) && time.Date(2024, 5, 10, 15, 0, 0, 0, time.UTC).Before(*p.To)
+			},
+			mockResponse: page,
+		},
+		{
+			name:               "Missing audit permission",
+			expectedStatusCode: fiber.StatusForbidden,
+			mockError:          &shared.ForbiddenError{Permission: string(domain.PermissionReadAuditEvents)},
+		},
+		{
+			name:               "Unexpected error",
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockError:          errors.New("database error"),
+		},
+		{
+			name:               "Invalid resource type",
+			query:              "?resource_type=tractor",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Invalid time range",
+			query:              "?from=yesterday",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Reversed time range",
+			query:              "?from=2024-02-01&to=2024-01-01",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		as.Run(tt.name, func() {
+			mockUseCase := new(MockListAuditEventsUseCase)
+			mockRequired := tt.mockResponse != nil || tt.mockError != nil
+			if mockRequired {
+				var parameters interface{} = mock.Anything
+				if tt.expectedParameters != nil {
+					parameters = mock.MatchedBy(tt.expectedParameters)
+				}
+				mockUseCase.On("Execute", mock.Anything, parameters).Return(tt.mockResponse, tt.mockError)
+			}
+			app := fiber.New()
+			app.Get("/audit-events", NewAuditEventController(mockUseCase, as.logger).ListAuditEvents)
+
+			req, err := http.NewRequest("GET", "/audit-events"+tt.query, nil)
+			assert.NoError(as.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(as.T(), err)
+
+			assert.Equal(as.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var response models.PaginatedResponse[*domain.AuditEvent]
+				assert.NoError(as.T(), json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(as.T(), page.Items[0].ID, response.Items[0].ID)
+				assert.Equal(as.T(), int64(1), response.TotalCount)
+			}
+			if mockRequired {
+				mockUseCase.AssertExpectations(as.T())
+			} else {
+				mockUseCase.AssertNotCalled(as.T(), "Execute", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuditEventControllerSuite(t *testing.T) {
+	suite.Run(t, new(AuditEventControllerTestSuite))
+}
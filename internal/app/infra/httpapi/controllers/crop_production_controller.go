@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain/usecases"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/dto"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CropProductionController struct {
+	listCropProductionsUseCase  usecases.ListCropProductionsUseCase
+	createCropProductionUseCase usecases.CreateCropProductionUseCase
+	patchCropProductionUseCase  usecases.PatchCropProductionUseCase
+	deleteCropProductionUseCase usecases.DeleteCropProductionUseCase
+	logger                      *logger.Logger
+}
+
+// cropProductionErrorResponse maps the errors returned by the crop production use cases to HTTP responses
+func (cc *CropProductionController) cropProductionErrorResponse(c *fiber.Ctx, err error) error {
+	var notFoundError *shared.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return c.Status(fiber.StatusNotFound).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	var forbiddenError *shared.ForbiddenError
+	if errors.As(err, &forbiddenError) || errors.Is(err, domain.ErrMissingOrganization) {
+		return c.Status(fiber.StatusForbidden).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	if errors.Is(err, domain.ErrInvalidCropType) || errors.Is(err, domain.ErrInvalidFarmID) {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	cc.logger.Error(requestContext(c), "Unexpected error", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
+		Error: "Internal server error",
+	})
+}
+
+func invalidIDResponse(c *fiber.Ctx, param string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+		Error: "The '" + param + "' parameter must be a valid ID.",
+	})
+}
+
+// @Summary List the crop productions of a farm
+// @Description Get all crop productions of the given farm
+// @Tags CropProduction
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Success 200 {array} domain.CropProduction "List of Crop Productions"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/crop-productions [get]
+func (cc *CropProductionController) ListCropProductions(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return invalidIDResponse(c, "id")
+	}
+	cropProductions, err := cc.listCropProductionsUseCase.Execute(requestContext(c), farmId)
+	if err != nil {
+		return cc.cropProductionErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(cropProductions)
+}
+
+// @Summary Add a crop production to a farm
+// @Description Creates a new crop production for the given farm
+// @Tags CropProduction
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param cropProduction body dto.CropProductionDTO true "Crop Production Data"
+// @Success 201 {object} domain.CropProduction "Crop Production Created"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/crop-productions [post]
+func (cc *CropProductionController) CreateCropProduction(c *fiber.Ctx) error {
+	farmId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return invalidIDResponse(c, "id")
+	}
+	var dto dto.CropProductionDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return err
+	}
+	if errs := dto.Validate(); len(errs) > 0 && errs[0].Error {
+		return validationErrorResponse(c, errs)
+	}
+	cropProduction, err := cc.createCropProductionUseCase.Execute(
+		requestContext(c),
+		farmId,
+		domain.CropType(dto.CropType),
+		dto.IsIrrigated,
+		dto.IsInsured,
+	)
+	if err != nil {
+		return cc.cropProductionErrorResponse(c, err)
+	}
+	c.Set("Location", "/farms/"+farmId.String()+"/crop-productions/"+cropProduction.ID.String())
+	return c.Status(fiber.StatusCreated).JSON(cropProduction)
+}
+
+// @Summary Partially update a crop production
+// @Description Updates only the provided crop production fields
+// @Tags CropProduction
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param cropId path string true "Crop Production ID"
+// @Param cropProduction body dto.PatchCropProductionDTO true "Crop Production Data"
+// @Success 200 {object} domain.CropProduction "Crop Production Updated"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/crop-productions/{cropId} [patch]
+func (cc *CropProductionController) PatchCropProduction(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return invalidIDResponse(c, "id")
+	}
+	cropProductionId := c.Params("cropId")
+	if _, err := uuid.Parse(cropProductionId); err != nil {
+		return invalidIDResponse(c, "cropId")
+	}
+	var dto dto.PatchCropProductionDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return err
+	}
+	if errs := dto.Validate(); len(errs) > 0 && errs[0].Error {
+		return validationErrorResponse(c, errs)
+	}
+	patch := domain.CropProductionPatch{
+		IsIrrigated: dto.IsIrrigated,
+		IsInsured:   dto.IsInsured,
+	}
+	if dto.CropType != nil {
+		cropType := domain.CropType(*dto.CropType)
+		patch.CropType = &cropType
+	}
+	cropProduction, err := cc.patchCropProductionUseCase.Execute(requestContext(c), farmId, cropProductionId, patch)
+	if err != nil {
+		return cc.cropProductionErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(cropProduction)
+}
+
+// @Summary Delete a crop production
+// @Description Removes a crop production from the given farm
+// @Tags CropProduction
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param cropId path string true "Crop Production ID"
+// @Success 204  "No Content"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/crop-productions/{cropId} [delete]
+func (cc *CropProductionController) DeleteCropProduction(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return invalidIDResponse(c, "id")
+	}
+	cropProductionId := c.Params("cropId")
+	if _, err := uuid.Parse(cropProductionId); err != nil {
+		return invalidIDResponse(c, "cropId")
+	}
+	if err := cc.deleteCropProductionUseCase.Execute(requestContext(c), farmId, cropProductionId); err != nil {
+		return cc.cropProductionErrorResponse(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func NewCropProductionController(
+	listCropProductionsUseCase usecases.ListCropProductionsUseCase,
+	createCropProductionUseCase usecases.CreateCropProductionUseCase,
+	patchCropProductionUseCase usecases.PatchCropProductionUseCase,
+	deleteCropProductionUseCase usecases.DeleteCropProductionUseCase,
+	logger *logger.Logger,
+) *CropProductionController {
+	return &CropProductionController{
+		listCropProductionsUseCase:  listCropProductionsUseCase,
+		createCropProductionUseCase: createCropProductionUseCase,
+		patchCropProductionUseCase:  patchCropProductionUseCase,
+		deleteCropProductionUseCase: deleteCropProductionUseCase,
+		logger:                      logger,
+	}
+}
@@ -0,0 +1,322 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockListCropProductionsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListCropProductionsUseCase) Execute(ctx context.Context, farmId string) ([]domain.CropProduction, error) {
+	args := m.Called(ctx, farmId)
+	return args.Get(0).([]domain.CropProduction), args.Error(1)
+}
+
+type MockCreateCropProductionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockCreateCropProductionUseCase) Execute(ctx context.Context, farmId uuid.UUID, cropType domain.CropType, isIrrigated bool, isInsured bool) (*domain.CropProduction, error) {
+	args := m.Called(ctx, farmId, cropType, isIrrigated, isInsured)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+type MockPatchCropProductionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPatchCropProductionUseCase) Execute(ctx context.Context, farmId string, cropProductionId string, patch domain.CropProductionPatch) (*domain.CropProduction, error) {
+	args := m.Called(ctx, farmId, cropProductionId, patch)
+	return args.Get(0).(*domain.CropProduction), args.Error(1)
+}
+
+type MockDeleteCropProductionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockDeleteCropProductionUseCase) Execute(ctx context.Context, farmId string, cropProductionId string) error {
+	args := m.Called(ctx, farmId, cropProductionId)
+	return args.Error(0)
+}
+
+type CropProductionControllerTestSuite struct {
+	suite.Suite
+	logger *logger.Logger
+}
+
+func (cs *CropProductionControllerTestSuite) SetupSuite() {
+	cs.logger = logger.NewLogger()
+}
+
+func (cs *CropProductionControllerTestSuite) newApp(controller *CropProductionController) *fiber.App {
+	app := fiber.New(fiber.Config{
+		AppName:       "farm-api-test by @arthurgavazza",
+		CaseSensitive: true,
+	})
+	app.Get("/farms/:id/crop-productions", controller.ListCropProductions)
+	app.Post("/farms/:id/crop-productions", controller.CreateCropProduction)
+	app.Patch("/farms/:id/crop-productions/:cropId", controller.PatchCropProduction)
+	app.Delete("/farms/:id/crop-productions/:cropId", controller.DeleteCropProduction)
+	return app
+}
+
+func (cs *CropProductionControllerTestSuite) TestListCropProductions() {
+	farmId := uuid.New()
+	tests := []struct {
+		name               string
+		farmId             string
+		expectedStatusCode int
+		mockResponse       []domain.CropProduction
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful crop productions retrieval",
+			farmId:             farmId.String(),
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse: []domain.CropProduction{
+				{ID: uuid.New(), FarmID: farmId, CropType: domain.CropTypeCoffee.String()},
+			},
+			mockRequired: true,
+		},
+		{
+			name:               "Farm not found",
+			farmId:             farmId.String(),
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farmId.String()},
+			mockRequired:       true,
+		},
+		{
+			name:               "Missing read permission",
+			farmId:             farmId.String(),
+			expectedStatusCode: fiber.StatusForbidden,
+			mockError:          &shared.ForbiddenError{Permission: string(domain.PermissionReadFarms)},
+			mockRequired:       true,
+		},
+		{
+			name:               "Malformed farm id",
+			farmId:             "invalid_id",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockListCropProductionsUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockListCropProductionsUseCase)
+				mockUseCase.On("Execute", mock.Anything, tt.farmId).Return(tt.mockResponse, tt.mockError)
+			}
+			app := cs.newApp(NewCropProductionController(mockUseCase, nil, nil, nil, cs.logger))
+
+			req, err := http.NewRequest("GET", fmt.Sprintf("/farms/%s/crop-productions", tt.farmId), nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var response []domain.CropProduction
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(cs.T(), err)
+				assert.Equal(cs.T(), tt.mockResponse[0].ID, response[0].ID)
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *CropProductionControllerTestSuite) TestCreateCropProduction() {
+	farmId := uuid.New()
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		mockResponse       *domain.CropProduction
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful crop production creation",
+			payload:            `{"crop_type": "CORN", "is_irrigated": true, "is_insured": false}`,
+			expectedStatusCode: fiber.StatusCreated,
+			mockResponse:       &domain.CropProduction{ID: uuid.New(), FarmID: farmId, CropType: domain.CropTypeCorn.String(), IsIrrigated: true},
+			mockRequired:       true,
+		},
+		{
+			name:               "Invalid crop type",
+			payload:            `{"crop_type": "BEANS"}`,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Farm not found",
+			payload:            `{"crop_type": "CORN", "is_irrigated": true, "is_insured": false}`,
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farmId.String()},
+			mockRequired:       true,
+		},
+		{
+			name:               "Unknown exception in use case layer",
+			payload:            `{"crop_type": "CORN", "is_irrigated": true, "is_insured": false}`,
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockError:          assert.AnError,
+			mockRequired:       true,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockCreateCropProductionUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockCreateCropProductionUseCase)
+				mockUseCase.On("Execute", mock.Anything, farmId, domain.CropTypeCorn, true, false).
+					Return(tt.mockResponse, tt.mockError)
+			}
+			app := cs.newApp(NewCropProductionController(nil, mockUseCase, nil, nil, cs.logger))
+
+			req, err := http.NewRequest("POST", fmt.Sprintf("/farms/%s/crop-productions", farmId), bytes.NewReader([]byte(tt.payload)))
+			assert.NoError(cs.T(), err)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusCreated {
+				assert.Equal(cs.T(), fmt.Sprintf("/farms/%s/crop-productions/%s", farmId, tt.mockResponse.ID), resp.Header.Get("Location"))
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *CropProductionControllerTestSuite) TestPatchCropProduction() {
+	farmId := uuid.New().String()
+	cropProductionId := uuid.New().String()
+	isInsured := true
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful crop production update",
+			payload:            `{"is_insured": true}`,
+			expectedStatusCode: fiber.StatusOK,
+			mockRequired:       true,
+		},
+		{
+			name:               "Crop production not found",
+			payload:            `{"is_insured": true}`,
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "CropProduction", ID: cropProductionId},
+			mockRequired:       true,
+		},
+		{
+			name:               "Invalid crop type",
+			payload:            `{"crop_type": "BEANS"}`,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockPatchCropProductionUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockPatchCropProductionUseCase)
+				mockUseCase.On("Execute", mock.Anything, farmId, cropProductionId, domain.CropProductionPatch{IsInsured: &isInsured}).
+					Return(&domain.CropProduction{}, tt.mockError)
+			}
+			app := cs.newApp(NewCropProductionController(nil, nil, mockUseCase, nil, cs.logger))
+
+			route := fmt.Sprintf("/farms/%s/crop-productions/%s", farmId, cropProductionId)
+			req, err := http.NewRequest("PATCH", route, bytes.NewReader([]byte(tt.payload)))
+			assert.NoError(cs.T(), err)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *CropProductionControllerTestSuite) TestDeleteCropProduction() {
+	farmId := uuid.New().String()
+	cropProductionId := uuid.New().String()
+	tests := []struct {
+		name               string
+		cropProductionId   string
+		expectedStatusCode int
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful crop production deletion",
+			cropProductionId:   cropProductionId,
+			expectedStatusCode: fiber.StatusNoContent,
+			mockRequired:       true,
+		},
+		{
+			name:               "Crop production not found",
+			cropProductionId:   cropProductionId,
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "CropProduction", ID: cropProductionId},
+			mockRequired:       true,
+		},
+		{
+			name:               "Malformed crop production id",
+			cropProductionId:   "invalid_id",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockDeleteCropProductionUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockDeleteCropProductionUseCase)
+				mockUseCase.On("Execute", mock.Anything, farmId, tt.cropProductionId).Return(tt.mockError)
+			}
+			app := cs.newApp(NewCropProductionController(nil, nil, nil, mockUseCase, cs.logger))
+
+			route := fmt.Sprintf("/farms/%s/crop-productions/%s", farmId, tt.cropProductionId)
+			req, err := http.NewRequest("DELETE", route, nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func TestCropProductionControllerSuite(t *testing.T) {
+	suite.Run(t, new(CropProductionControllerTestSuite))
+}
@@ -3,7 +3,6 @@ package controllers
 import (
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
@@ -11,14 +10,114 @@ import (
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/dto"
 	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
 	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	validation "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/validation"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type FarmController struct {
-	createFarmUsecase usecases.CreateFarmUseCase
-	listFarmsUseCase  usecases.ListFarmsUseCase
-	deleteFarmUseCase usecases.DeleteFarmUseCase
-	logger            *logger.Logger
+	createFarmUsecase        usecases.CreateFarmUseCase
+	listFarmsUseCase         usecases.ListFarmsUseCase
+	listFarmsByCursorUseCase usecases.ListFarmsByCursorUseCase
+	deleteFarmUseCase        usecases.DeleteFarmUseCase
+	getFarmUseCase           usecases.GetFarmUseCase
+	updateFarmUseCase        usecases.UpdateFarmUseCase
+	patchFarmUseCase         usecases.PatchFarmUseCase
+	restoreFarmUseCase       usecases.RestoreFarmUseCase
+	purgeFarmUseCase         usecases.PurgeFarmUseCase
+	getFarmStatsUseCase      usecases.GetFarmStatsUseCase
+	importFarmsUseCase       usecases.ImportFarmsUseCase
+	exportFarmsUseCase       usecases.ExportFarmsUseCase
+	logger                   *logger.Logger
+}
+
+func validationErrorResponse(c *fiber.Ctx, errs []validation.ErrorResponse) error {
+	return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{Error: strings.Join(validationErrorMessages(errs), " and ")})
+}
+
+func validationErrorMessages(errs []validation.ErrorResponse) []string {
+	errMsgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		errMsgs = append(errMsgs, fmt.Sprintf(
+			"[%s]: '%v' | Needs to implement '%s'",
+			err.FailedField,
+			err.Value,
+			err.Tag,
+		))
+	}
+	return errMsgs
+}
+
+// toDomainFarm maps a validated farm creation DTO to a new domain farm
+func toDomainFarm(farmDTO dto.CreateFarmDTO) domain.Farm {
+	var productions []domain.CropProduction
+	for _, production := range farmDTO.CropProductions {
+		domainCropProduction := domain.CropProduction{
+			CropType:    production.CropType,
+			IsInsured:   production.IsInsured,
+			IsIrrigated: production.IsIrrigated,
+		}
+		productions = append(productions, domainCropProduction)
+	}
+	return domain.Farm{
+		Name:            farmDTO.Name,
+		LandArea:        farmDTO.LandArea,
+		UnitMeasure:     toDomainUnitMeasure(farmDTO.UnitMeasure),
+		Address:         farmDTO.Address,
+		CropProductions: productions,
+	}
+}
+
+// toDomainUnitMeasure returns the canonical unit measure of a unit already checked by the DTO validation
+func toDomainUnitMeasure(value string) domain.UnitMeasure {
+	unitMeasure, _ := domain.ParseUnitMeasure(value)
+	return unitMeasure
+}
+
+// convertLandAreas expresses the farms land areas in the requested unit, farms are left untouched when no unit is requested
+func convertLandAreas(farms []*domain.Farm, unit domain.UnitMeasure) {
+	if unit == "" {
+		return
+	}
+	for _, farm := range farms {
+		farm.ConvertLandArea(unit)
+	}
+}
+
+func toDomainCropProductions(productions []dto.UpdateCropProductionDTO) []domain.CropProduction {
+	domainProductions := make([]domain.CropProduction, 0, len(productions))
+	for _, production := range productions {
+		domainCropProduction := domain.CropProduction{
+			CropType:    production.CropType,
+			IsInsured:   production.IsInsured,
+			IsIrrigated: production.IsIrrigated,
+		}
+		if production.ID != "" {
+			domainCropProduction.ID = uuid.MustParse(production.ID)
+		}
+		domainProductions = append(domainProductions, domainCropProduction)
+	}
+	return domainProductions
+}
+
+// farmErrorResponse maps the errors returned by the farm use cases to HTTP responses
+func (fc *FarmController) farmErrorResponse(c *fiber.Ctx, err error) error {
+	var notFoundError *shared.NotFoundError
+	if errors.As(err, &notFoundError) {
+		return c.Status(fiber.StatusNotFound).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	var forbiddenError *shared.ForbiddenError
+	if errors.As(err, &forbiddenError) || errors.Is(err, domain.ErrMissingOrganization) {
+		return c.Status(fiber.StatusForbidden).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	fc.logger.Error(requestContext(c), "Unexpected error", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
+		Error: "Internal server error",
+	})
 }
 
 // FarmController handles the operations related to farms
@@ -30,7 +129,10 @@ type FarmController struct {
 // @Param farm body dto.CreateFarmDTO true "Farm Data"
 // @Success 201 {object} domain.Farm "Farm Created"
 // @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
 // @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /farms [post]
 func (fc *FarmController) CreateFarm(c *fiber.Ctx) error {
 	var dto dto.CreateFarmDTO
@@ -38,38 +140,11 @@ func (fc *FarmController) CreateFarm(c *fiber.Ctx) error {
 		return err
 	}
 	if errs := dto.Validate(); len(errs) > 0 && errs[0].Error {
-		errMsgs := make([]string, 0)
-		for _, err := range errs {
-			errMsgs = append(errMsgs, fmt.Sprintf(
-				"[%s]: '%v' | Needs to implement '%s'",
-				err.FailedField,
-				err.Value,
-				err.Tag,
-			))
-		}
-		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{Error: strings.Join(errMsgs, " and ")})
+		return validationErrorResponse(c, errs)
 	}
-	var productions []domain.CropProduction
-	for _, production := range dto.CropProductions {
-		domainCropProduction := domain.CropProduction{
-			CropType:    production.CropType,
-			IsInsured:   production.IsInsured,
-			IsIrrigated: production.IsIrrigated,
-		}
-		productions = append(productions, domainCropProduction)
-	}
-	farm, err := fc.createFarmUsecase.Execute(c.Context(), domain.Farm{
-		Name:            dto.Name,
-		LandArea:        dto.LandArea,
-		UnitMeasure:     dto.UnitMeasure,
-		Address:         dto.Address,
-		CropProductions: productions,
-	})
+	farm, err := fc.createFarmUsecase.Execute(requestContext(c), toDomainFarm(dto))
 	if err != nil {
-		fc.logger.Error(c.Context(), "Unexpected error", err)
-		return c.
-			Status(fiber.StatusInternalServerError).
-			JSON(shared.CustomError{Error: "internal server error"})
+		return fc.farmErrorResponse(c, err)
 	}
 	c.Set("Location", "/farms/"+farm.ID.String())
 	return c.Status(fiber.StatusCreated).JSON(farm)
@@ -82,52 +157,262 @@ func (fc *FarmController) CreateFarm(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page" default(1)
 // @Param per_page query int false "Items per page" default(10)
-// @Param crop_type query string false "Crop Type Filter"
-// @Param minimum_land_area query float64 false "Minimum Land Area"
-// @Param maximum_land_area query float64 false "Maximum Land Area"
+// @Param name query string false "Case-insensitive farm name substring"
+// @Param address query string false "Case-insensitive farm address substring"
+// @Param crop_type query []string false "Crop types, repeated or comma separated" collectionFormat(multi)
+// @Param crop_type_match query string false "Whether farms must produce any or all of the crop types" Enums(any, all) default(any)
+// @Param is_irrigated query bool false "Only farms with an irrigated (or non irrigated) crop production"
+// @Param is_insured query bool false "Only farms with an insured (or non insured) crop production"
+// @Param unit_measure query string false "Unit Measure" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param minimum_land_area query float64 false "Minimum Land Area, in hectares unless unit is given"
+// @Param maximum_land_area query float64 false "Maximum Land Area, in hectares unless unit is given"
+// @Param unit query string false "Unit of the land area range and of the returned land areas" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param created_after query string false "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param created_before query string false "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date"
+// @Param cursor query string false "Cursor returned in the next_cursor field of the previous page, enables the cursor pagination mode"
+// @Param limit query int false "Items per page in the cursor pagination mode" default(10)
+// @Param include_deleted query bool false "Include deleted farms"
+// @Param only_deleted query bool false "Only list deleted farms"
+// @Param sort query string false "Comma separated sort fields (name, land_area, created_at, updated_at), prefix a field with - to sort in descending order" example(-land_area,name)
 // @Success 200 {array} domain.Farm "List of Farms"
 // @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
 // @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /farms [get]
 func (fc *FarmController) ListFarms(c *fiber.Ctx) error {
 	queries := c.Queries()
-	searchParameters := &domain.FarmSearchParameters{
-		Page:    c.QueryInt("page", 1),
-		PerPage: c.QueryInt("per_page", 10),
+	searchParameters, err := parseFarmSearchFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
 	}
-	if cropType, exists := queries["crop_type"]; exists {
-		searchParameters.CropType = &cropType
+	searchParameters.Page = c.QueryInt("page", 1)
+	searchParameters.PerPage = c.QueryInt("per_page", 10)
+	if sortStr, exists := queries["sort"]; exists {
+		sort, err := domain.ParseFarmSort(sortStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+				Error: `Query parameter "sort" must be a comma separated list of name, land_area, created_at or updated_at, optionally prefixed with -`,
+			})
+		}
+		searchParameters.Sort = sort
 	}
 
-	if minLandAreaStr, exists := queries["minimum_land_area"]; exists {
-		landArea, err := strconv.ParseFloat(minLandAreaStr, 64)
-		if err != nil {
+	// the keyset pagination mode is used whenever the client sends a cursor or a limit
+	_, hasCursor := queries["cursor"]
+	_, hasLimit := queries["limit"]
+	if hasCursor || hasLimit {
+		if len(searchParameters.Sort) > 0 {
 			return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
-				Error: `Query parameter "minimum_land_area" must be a valid floating-point number`,
+				Error: `Query parameter "sort" is not supported in the cursor pagination mode`,
 			})
 		}
-		searchParameters.MinimumLandArea = &landArea
+		return fc.listFarmsByCursor(c, searchParameters)
+	}
+
+	result, err := fc.listFarmsUseCase.Execute(requestContext(c), searchParameters)
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
 	}
-	if maximumLandAreaStr, exists := queries["maximum_land_area"]; exists {
-		landArea, err := strconv.ParseFloat(maximumLandAreaStr, 64)
+	convertLandAreas(result.Items, searchParameters.LandAreaUnit)
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+func (fc *FarmController) listFarmsByCursor(c *fiber.Ctx, searchParameters *domain.FarmSearchParameters) error {
+	searchParameters.Limit = c.QueryInt("limit", 10)
+	if encodedCursor := c.Query("cursor"); encodedCursor != "" {
+		cursor, err := domain.DecodeFarmCursor(encodedCursor)
 		if err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
-				Error: `Query parameter "maximum_land_area" must be a valid floating-point number`,
+				Error: `Query parameter "cursor" must be a cursor returned by a previous request`,
 			})
 		}
-		searchParameters.MaximumLandArea = &landArea
+		searchParameters.Cursor = cursor
 	}
 
-	result, err := fc.listFarmsUseCase.Execute(c.Context(), searchParameters)
+	result, err := fc.listFarmsByCursorUseCase.Execute(requestContext(c), searchParameters)
 	if err != nil {
-		fc.logger.Error(c.Context(), "Unexpected error", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
-			Error: "Internal server error",
-		})
+		return fc.farmErrorResponse(c, err)
 	}
+	convertLandAreas(result.Items, searchParameters.LandAreaUnit)
 	return c.Status(fiber.StatusOK).JSON(result)
 }
 
+// @Summary Farm statistics
+// @Description Aggregates the farms matching the same filters as the farms listing: farm count, land areas, crop type counts and irrigated/insured ratios, overall and grouped by unit measure
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param name query string false "Case-insensitive farm name substring"
+// @Param address query string false "Case-insensitive farm address substring"
+// @Param crop_type query []string false "Crop types, repeated or comma separated" collectionFormat(multi)
+// @Param crop_type_match query string false "Whether farms must produce any or all of the crop types" Enums(any, all) default(any)
+// @Param is_irrigated query bool false "Only farms with an irrigated (or non irrigated) crop production"
+// @Param is_insured query bool false "Only farms with an insured (or non insured) crop production"
+// @Param unit_measure query string false "Unit Measure" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param minimum_land_area query float64 false "Minimum Land Area, in hectares unless unit is given"
+// @Param maximum_land_area query float64 false "Maximum Land Area, in hectares unless unit is given"
+// @Param unit query string false "Unit of the land area range and of the overall land areas" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param created_after query string false "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param created_before query string false "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date"
+// @Param include_deleted query bool false "Include deleted farms"
+// @Param only_deleted query bool false "Only aggregate deleted farms"
+// @Success 200 {object} domain.FarmStats "Farm Statistics"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/stats [get]
+func (fc *FarmController) GetFarmStats(c *fiber.Ctx) error {
+	searchParameters, err := parseFarmSearchFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+
+	stats, err := fc.getFarmStatsUseCase.Execute(requestContext(c), searchParameters)
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	stats.ConvertLandArea(searchParameters.LandAreaUnit)
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+// @Summary Get a farm by ID
+// @Description Retrieves a farm and its crop productions by the farm unique ID
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param unit query string false "Unit of the returned land area" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Success 200 {object} domain.Farm "Farm"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id} [get]
+func (fc *FarmController) GetFarm(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "The 'id' parameter must be a valid farm ID.",
+		})
+	}
+
+	landAreaUnit, err := parseLandAreaUnit(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+
+	farm, err := fc.getFarmUseCase.Execute(requestContext(c), farmId)
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	farm.ConvertLandArea(landAreaUnit)
+	return c.Status(fiber.StatusOK).JSON(farm)
+}
+
+// @Summary Replace a farm
+// @Description Replaces a farm and its crop productions. Crop productions without an ID are created, the ones that are not sent are removed
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param farm body dto.UpdateFarmDTO true "Farm Data"
+// @Success 200 {object} domain.Farm "Farm Updated"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id} [put]
+func (fc *FarmController) UpdateFarm(c *fiber.Ctx) error {
+	farmId, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "The 'id' parameter must be a valid farm ID.",
+		})
+	}
+	var dto dto.UpdateFarmDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return err
+	}
+	if errs := dto.Validate(); len(errs) > 0 && errs[0].Error {
+		return validationErrorResponse(c, errs)
+	}
+	farm, err := fc.updateFarmUseCase.Execute(requestContext(c), domain.Farm{
+		ID:              farmId,
+		Name:            dto.Name,
+		LandArea:        dto.LandArea,
+		UnitMeasure:     toDomainUnitMeasure(dto.UnitMeasure),
+		Address:         dto.Address,
+		CropProductions: toDomainCropProductions(dto.CropProductions),
+	})
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(farm)
+}
+
+// @Summary Partially update a farm
+// @Description Updates only the provided farm fields. When crop_productions is provided it replaces the farm crop productions
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Param farm body dto.PatchFarmDTO true "Farm Data"
+// @Success 200 {object} domain.Farm "Farm Updated"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id} [patch]
+func (fc *FarmController) PatchFarm(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "The 'id' parameter must be a valid farm ID.",
+		})
+	}
+	var dto dto.PatchFarmDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return err
+	}
+	if errs := dto.Validate(); len(errs) > 0 && errs[0].Error {
+		return validationErrorResponse(c, errs)
+	}
+	patch := domain.FarmPatch{
+		Name:     dto.Name,
+		LandArea: dto.LandArea,
+		Address:  dto.Address,
+	}
+	if dto.UnitMeasure != nil {
+		unitMeasure := toDomainUnitMeasure(*dto.UnitMeasure)
+		patch.UnitMeasure = &unitMeasure
+	}
+	if dto.CropProductions != nil {
+		productions := toDomainCropProductions(*dto.CropProductions)
+		patch.CropProductions = &productions
+	}
+	farm, err := fc.patchFarmUseCase.Execute(requestContext(c), farmId, patch)
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(farm)
+}
+
 func (fc *FarmController) DeleteFarm(c *fiber.Ctx) error {
 	farmId := c.Params("id")
 	if farmId == "" {
@@ -136,19 +421,65 @@ func (fc *FarmController) DeleteFarm(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := fc.deleteFarmUseCase.Execute(c.Context(), farmId); err != nil {
-		var notFoundError *shared.NotFoundError
-		if errors.As(err, &notFoundError) {
-			return c.Status(fiber.StatusNotFound).JSON(shared.CustomError{
-				Error: err.Error(),
-			})
-		}
-		fc.logger.Error(c.Context(), "Unexpected error", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
-			Error: fmt.Sprintf("Failed to delete farm: %s", err.Error()),
+	if err := fc.deleteFarmUseCase.Execute(requestContext(c), farmId); err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Restore a deleted farm
+// @Description Restores a deleted farm together with the crop productions that were deleted with it
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Success 200 {object} domain.Farm "Farm Restored"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/restore [post]
+func (fc *FarmController) RestoreFarm(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "The 'id' parameter must be a valid farm ID.",
 		})
 	}
+	farm, err := fc.restoreFarmUseCase.Execute(requestContext(c), farmId)
+	if err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(farm)
+}
 
+// @Summary Permanently delete a farm
+// @Description Permanently removes a farm, deleted or not, and all of its crop productions. This operation can't be undone
+// @Tags Farm
+// @Accept json
+// @Produce json
+// @Param id path string true "Farm ID"
+// @Success 204  "No Content"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/{id}/purge [delete]
+func (fc *FarmController) PurgeFarm(c *fiber.Ctx) error {
+	farmId := c.Params("id")
+	if _, err := uuid.Parse(farmId); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "The 'id' parameter must be a valid farm ID.",
+		})
+	}
+	if err := fc.purgeFarmUseCase.Execute(requestContext(c), farmId); err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
@@ -161,18 +492,39 @@ func (fc *FarmController) DeleteFarm(c *fiber.Ctx) error {
 // @Success 204  "No Content"
 // @Failure 400 {object} shared.CustomError "Bad Request"
 // @Failure 404 {object} shared.CustomError "Not Found"
+// @Failure 403 {object} shared.CustomError "Forbidden"
 // @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /farms/{id} [delete]
 func NewFarmController(
 	createFarmUsecase usecases.CreateFarmUseCase,
 	listFarmsUsecase usecases.ListFarmsUseCase,
 	deleteFarmUseCase usecases.DeleteFarmUseCase,
+	getFarmUseCase usecases.GetFarmUseCase,
+	updateFarmUseCase usecases.UpdateFarmUseCase,
+	patchFarmUseCase usecases.PatchFarmUseCase,
+	restoreFarmUseCase usecases.RestoreFarmUseCase,
+	purgeFarmUseCase usecases.PurgeFarmUseCase,
+	listFarmsByCursorUseCase usecases.ListFarmsByCursorUseCase,
+	getFarmStatsUseCase usecases.GetFarmStatsUseCase,
+	importFarmsUseCase usecases.ImportFarmsUseCase,
+	exportFarmsUseCase usecases.ExportFarmsUseCase,
 	logger *logger.Logger,
 ) *FarmController {
 	return &FarmController{
-		createFarmUsecase: createFarmUsecase,
-		listFarmsUseCase:  listFarmsUsecase,
-		deleteFarmUseCase: deleteFarmUseCase,
-		logger:            logger,
+		createFarmUsecase:        createFarmUsecase,
+		listFarmsUseCase:         listFarmsUsecase,
+		deleteFarmUseCase:        deleteFarmUseCase,
+		getFarmUseCase:           getFarmUseCase,
+		updateFarmUseCase:        updateFarmUseCase,
+		patchFarmUseCase:         patchFarmUseCase,
+		restoreFarmUseCase:       restoreFarmUseCase,
+		purgeFarmUseCase:         purgeFarmUseCase,
+		listFarmsByCursorUseCase: listFarmsByCursorUseCase,
+		getFarmStatsUseCase:      getFarmStatsUseCase,
+		importFarmsUseCase:       importFarmsUseCase,
+		exportFarmsUseCase:       exportFarmsUseCase,
+		logger:                   logger,
 	}
 }
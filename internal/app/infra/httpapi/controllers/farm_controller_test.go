@@ -6,8 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/dto"
@@ -49,6 +53,99 @@ func (m *MockDeleteFarmUseCase) Execute(ctx context.Context, farmId string) erro
 	return args.Error(0)
 }
 
+type MockGetFarmUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetFarmUseCase) Execute(ctx context.Context, farmId string) (*domain.Farm, error) {
+	args := m.Called(ctx, farmId)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+type MockUpdateFarmUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUpdateFarmUseCase) Execute(ctx context.Context, farm domain.Farm) (*domain.Farm, error) {
+	args := m.Called(ctx, farm)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+type MockPatchFarmUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPatchFarmUseCase) Execute(ctx context.Context, farmId string, patch domain.FarmPatch) (*domain.Farm, error) {
+	args := m.Called(ctx, farmId, patch)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+type MockRestoreFarmUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRestoreFarmUseCase) Execute(ctx context.Context, farmId string) (*domain.Farm, error) {
+	args := m.Called(ctx, farmId)
+	return args.Get(0).(*domain.Farm), args.Error(1)
+}
+
+type MockPurgeFarmUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPurgeFarmUseCase) Execute(ctx context.Context, farmId string) error {
+	args := m.Called(ctx, farmId)
+	return args.Error(0)
+}
+
+type MockListFarmsByCursorUseCase struct {
+	mock.Mock
+}
+
+func (m *MockListFarmsByCursorUseCase) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*models.CursorPaginatedResponse[*domain.Farm], error) {
+	args := m.Called(ctx, searchParameters)
+	return args.Get(0).(*models.CursorPaginatedResponse[*domain.Farm]), args.Error(1)
+}
+
+type MockGetFarmStatsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetFarmStatsUseCase) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters) (*domain.FarmStats, error) {
+	args := m.Called(ctx, searchParameters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FarmStats), args.Error(1)
+}
+
+type MockImportFarmsUseCase struct {
+	mock.Mock
+}
+
+func (m *MockImportFarmsUseCase) Execute(ctx context.Context, farms []domain.Farm, atomic bool) ([]*domain.Farm, []error, error) {
+	args := m.Called(ctx, farms, atomic)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Farm), args.Get(1).([]error), args.Error(2)
+}
+
+type MockExportFarmsUseCase struct {
+	mock.Mock
+	batches [][]*domain.Farm
+}
+
+func (m *MockExportFarmsUseCase) Execute(ctx context.Context, searchParameters *domain.FarmSearchParameters, batchSize int, handle func(farms []*domain.Farm) error) error {
+	args := m.Called(ctx, searchParameters, batchSize)
+	for _, batch := range m.batches {
+		if err := handle(batch); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
 type FarmControllerTestSuite struct {
 	suite.Suite
 	logger *logger.Logger
@@ -107,6 +204,20 @@ func (cs *FarmControllerTestSuite) TestFarmControllerCreateFarm() {
 			mockError:          nil,
 			mockRequired:       false,
 		},
+		{
+			name: "Bad Request - Invalid Unit Measure",
+			inputDTO: dto.CreateFarmDTO{
+				Name:            "Test Farm",
+				LandArea:        100.5,
+				UnitMeasure:     "feet",
+				Address:         "123 Farm Lane",
+				CropProductions: []dto.CropProductionDTO{},
+			},
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+		},
 		{
 			name: "Internal Server Error - Mock Use Case Error",
 			inputDTO: dto.CreateFarmDTO{
@@ -131,7 +242,7 @@ func (cs *FarmControllerTestSuite) TestFarmControllerCreateFarm() {
 					Return(tt.mockResponse, tt.mockError)
 			}
 
-			controller := NewFarmController(mockUseCase, nil, nil, cs.logger)
+			controller := NewFarmController(mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
 			app := fiber.New(fiber.Config{
 				AppName:       "farm-api-test by @arthurgavazza",
 				CaseSensitive: true,
@@ -210,6 +321,117 @@ func (cs *FarmControllerTestSuite) TestFarmControllerListFarms() {
 			mockRequired:       false,
 			queryString:        "?maximum_land_area=test",
 		},
+		{
+			name:               "Successful deleted farms retrieval",
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse: &models.PaginatedResponse[*domain.Farm]{
+				TotalCount:  1,
+				PerPage:     10,
+				CurrentPage: 1,
+				Items:       testutils.GenerateFarms(1, nil, nil),
+			},
+			mockError:    nil,
+			mockRequired: true,
+			queryString:  "?only_deleted=true",
+		},
+		{
+			name:               "Invalid include_deleted query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?include_deleted=maybe",
+		},
+		{
+			name:               "Successful sorted farms retrieval",
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse: &models.PaginatedResponse[*domain.Farm]{
+				TotalCount:  2,
+				PerPage:     10,
+				CurrentPage: 1,
+				Items:       testutils.GenerateFarms(2, nil, nil),
+			},
+			mockError:    nil,
+			mockRequired: true,
+			queryString:  "?sort=-land_area,name",
+		},
+		{
+			name:               "Successful farms retrieval with the richer filters",
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse: &models.PaginatedResponse[*domain.Farm]{
+				TotalCount:  1,
+				PerPage:     10,
+				CurrentPage: 1,
+				Items:       testutils.GenerateFarms(1, nil, nil),
+			},
+			mockError:    nil,
+			mockRequired: true,
+			queryString:  "?name=sunny&crop_type=COFFEE,RICE&crop_type=CORN&crop_type_match=all&is_irrigated=true&created_after=2024-01-01",
+		},
+		{
+			name:               "Invalid crop_type query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?crop_type=COFFEE,BEANS",
+		},
+		{
+			name:               "Invalid crop_type_match query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?crop_type=COFFEE&crop_type_match=some",
+		},
+		{
+			name:               "Invalid is_insured query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?is_insured=perhaps",
+		},
+		{
+			name:               "Invalid created_before query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?created_before=yesterday",
+		},
+		{
+			name:               "Invalid unit query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?minimum_land_area=10&unit=feet",
+		},
+		{
+			name:               "Invalid unit_measure query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?unit_measure=feet",
+		},
+		{
+			name:               "Invalid sort query parameter",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?sort=address",
+		},
+		{
+			name:               "Sort query parameter in the cursor pagination mode",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			queryString:        "?sort=name&limit=5",
+		},
 		{
 			name:               "Unknown exception in use case layer",
 			expectedStatusCode: fiber.StatusInternalServerError,
@@ -229,7 +451,7 @@ func (cs *FarmControllerTestSuite) TestFarmControllerListFarms() {
 					Return(tt.mockResponse, tt.mockError)
 			}
 
-			controller := NewFarmController(nil, mockUseCase, nil, cs.logger)
+			controller := NewFarmController(nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
 			app := fiber.New(fiber.Config{
 				AppName:       "farm-api-test by @arthurgavazza",
 				CaseSensitive: true,
@@ -267,6 +489,52 @@ func (cs *FarmControllerTestSuite) TestFarmControllerListFarms() {
 	}
 }
 
+func (cs *FarmControllerTestSuite) TestFarmControllerListFarmsParsesFilters() {
+	mockUseCase := new(MockListFarmsUseCase)
+	mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(searchParameters *domain.FarmSearchParameters) bool {
+		return *searchParameters.Name == "sunny" &&
+			*searchParameters.Address == "lane" &&
+			assert.ObjectsAreEqual([]domain.CropType{domain.CropTypeCoffee, domain.CropTypeRice, domain.CropTypeCorn}, searchParameters.CropTypes) &&
+			searchParameters.CropTypeMatch == domain.CropTypeMatchAll &&
+			*searchParameters.IsIrrigated &&
+			!*searchParameters.IsInsured &&
+			*searchParameters.UnitMeasure == "hectares" &&
+			searchParameters.CreatedAfter.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+			searchParameters.CreatedBefore.Equal(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	})).Return(&models.PaginatedResponse[*domain.Farm]{Items: []*domain.Farm{}}, nil)
+
+	controller := NewFarmController(nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+	app := fiber.New()
+	app.Get("/farms", controller.ListFarms)
+	req, err := http.NewRequest("GET", "/farms?name=sunny&address=lane&crop_type=coffee,RICE&crop_type=CORN&crop_type_match=all"+
+		"&is_irrigated=true&is_insured=false&unit_measure=hectares&created_after=2024-01-01&created_before=2024-06-01T12:00:00Z", nil)
+	assert.NoError(cs.T(), err)
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusOK, resp.StatusCode)
+	mockUseCase.AssertExpectations(cs.T())
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerListFarmsCreatedBeforeDateIncludesTheWholeDay() {
+	mockUseCase := new(MockListFarmsUseCase)
+	// created_before is an exclusive bound, a date is read as the start of the next day
+	mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(searchParameters *domain.FarmSearchParameters) bool {
+		return searchParameters.CreatedBefore.Equal(time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	})).Return(&models.PaginatedResponse[*domain.Farm]{Items: []*domain.Farm{}}, nil)
+
+	controller := NewFarmController(nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+	app := fiber.New()
+	app.Get("/farms", controller.ListFarms)
+	req, err := http.NewRequest("GET", "/farms?created_before=2024-05-01", nil)
+	assert.NoError(cs.T(), err)
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusOK, resp.StatusCode)
+	mockUseCase.AssertExpectations(cs.T())
+}
+
 func (cs *FarmControllerTestSuite) TestFarmControllerDeleteFarm() {
 	farmId := uuid.New().String()
 	notFoundErr := &shared.NotFoundError{
@@ -305,7 +573,7 @@ func (cs *FarmControllerTestSuite) TestFarmControllerDeleteFarm() {
 					Return(tt.mockError)
 			}
 
-			controller := NewFarmController(nil, nil, mockUseCase, cs.logger)
+			controller := NewFarmController(nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
 			app := fiber.New(fiber.Config{
 				AppName:       "farm-api-test by @arthurgavazza",
 				CaseSensitive: true,
@@ -331,6 +599,1135 @@ func (cs *FarmControllerTestSuite) TestFarmControllerDeleteFarm() {
 	}
 }
 
+func (cs *FarmControllerTestSuite) TestFarmControllerGetFarm() {
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	notFoundErr := &shared.NotFoundError{
+		Resource: "Farm",
+		ID:       farm.ID.String(),
+	}
+	tests := []struct {
+		name               string
+		expectedStatusCode int
+		mockResponse       *domain.Farm
+		mockError          error
+		mockRequired       bool
+		farmId             string
+	}{
+		{
+			name:               "Successful farm retrieval",
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse:       farm,
+			mockError:          nil,
+			mockRequired:       true,
+			farmId:             farm.ID.String(),
+		},
+		{
+			name:               "Invalid request - farm not found",
+			expectedStatusCode: fiber.StatusNotFound,
+			mockResponse:       nil,
+			mockError:          notFoundErr,
+			mockRequired:       true,
+			farmId:             farm.ID.String(),
+		},
+		{
+			name:               "Invalid request - malformed farm id",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockResponse:       nil,
+			mockError:          nil,
+			mockRequired:       false,
+			farmId:             "invalid_id",
+		},
+		{
+			name:               "Unknown exception in use case layer",
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockResponse:       nil,
+			mockError:          errors.New("Unknown error"),
+			mockRequired:       true,
+			farmId:             farm.ID.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockGetFarmUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockGetFarmUseCase)
+				mockUseCase.On("Execute", mock.Anything, tt.farmId).
+					Return(tt.mockResponse, tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Get("/farms/:id", controller.GetFarm)
+			route := fmt.Sprintf("/farms/%s", tt.farmId)
+			req, err := http.NewRequest("GET", route, nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var responseFarm domain.Farm
+				err = json.NewDecoder(resp.Body).Decode(&responseFarm)
+				assert.NoError(cs.T(), err)
+				assert.Equal(cs.T(), tt.mockResponse.ID, responseFarm.ID)
+				assert.Equal(cs.T(), len(tt.mockResponse.CropProductions), len(responseFarm.CropProductions))
+			} else {
+				var response map[string]interface{}
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(cs.T(), err)
+				assert.NotNil(cs.T(), response["error"])
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerGetFarmConvertsLandArea() {
+	farm := testutils.GenerateFakeFarm(nil, testutils.PointerTo(float64(10)))
+	farm.UnitMeasure = domain.UnitMeasureHectares
+	mockUseCase := new(MockGetFarmUseCase)
+	mockUseCase.On("Execute", mock.Anything, farm.ID.String()).Return(farm, nil)
+
+	controller := NewFarmController(nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+	app := fiber.New()
+	app.Get("/farms/:id", controller.GetFarm)
+	req, err := http.NewRequest("GET", fmt.Sprintf("/farms/%s?unit=m2", farm.ID), nil)
+	assert.NoError(cs.T(), err)
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusOK, resp.StatusCode)
+	var responseFarm domain.Farm
+	err = json.NewDecoder(resp.Body).Decode(&responseFarm)
+	assert.NoError(cs.T(), err)
+	assert.Equal(cs.T(), domain.UnitMeasureSquareMeters, responseFarm.UnitMeasure)
+	assert.InDelta(cs.T(), 100000, responseFarm.LandArea, 1e-6)
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerUpdateFarm() {
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	validDTO := dto.UpdateFarmDTO{
+		Name:        "Updated Farm",
+		LandArea:    200,
+		UnitMeasure: "hectares",
+		Address:     "456 Farm Road",
+		CropProductions: []dto.UpdateCropProductionDTO{
+			{
+				ID:          farm.CropProductions[0].ID.String(),
+				CropType:    domain.CropTypeCorn.String(),
+				IsIrrigated: false,
+				IsInsured:   true,
+			},
+			{
+				CropType: domain.CropTypeRice.String(),
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		farmId             string
+		inputDTO           dto.UpdateFarmDTO
+		expectedStatusCode int
+		mockResponse       *domain.Farm
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful farm update",
+			farmId:             farm.ID.String(),
+			inputDTO:           validDTO,
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse:       farm,
+			mockRequired:       true,
+		},
+		{
+			name:   "Bad Request - Missing required fields",
+			farmId: farm.ID.String(),
+			inputDTO: dto.UpdateFarmDTO{
+				Name: "Updated Farm",
+			},
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Bad Request - Malformed farm id",
+			farmId:             "invalid_id",
+			inputDTO:           validDTO,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Not Found - Farm does not exist",
+			farmId:             farm.ID.String(),
+			inputDTO:           validDTO,
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farm.ID.String()},
+			mockRequired:       true,
+		},
+		{
+			name:               "Internal Server Error - Mock Use Case Error",
+			farmId:             farm.ID.String(),
+			inputDTO:           validDTO,
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockError:          assert.AnError,
+			mockRequired:       true,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockUpdateFarmUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockUpdateFarmUseCase)
+				mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(f domain.Farm) bool {
+					return f.ID.String() == tt.farmId &&
+						len(f.CropProductions) == 2 &&
+						f.CropProductions[0].ID == farm.CropProductions[0].ID &&
+						f.CropProductions[1].ID == uuid.Nil
+				})).Return(tt.mockResponse, tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Put("/farms/:id", controller.UpdateFarm)
+
+			payload, err := json.Marshal(tt.inputDTO)
+			assert.NoError(cs.T(), err)
+			req, err := http.NewRequest("PUT", fmt.Sprintf("/farms/%s", tt.farmId), bytes.NewReader(payload))
+			assert.NoError(cs.T(), err)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var responseFarm domain.Farm
+				err = json.NewDecoder(resp.Body).Decode(&responseFarm)
+				assert.NoError(cs.T(), err)
+				assert.Equal(cs.T(), tt.mockResponse.ID, responseFarm.ID)
+			} else {
+				var response map[string]interface{}
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(cs.T(), err)
+				assert.NotNil(cs.T(), response["error"])
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerPatchFarm() {
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	tests := []struct {
+		name               string
+		farmId             string
+		payload            string
+		expectedStatusCode int
+		expectedPatch      domain.FarmPatch
+		mockResponse       *domain.Farm
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful partial update",
+			farmId:             farm.ID.String(),
+			payload:            `{"name": "Renamed Farm"}`,
+			expectedStatusCode: fiber.StatusOK,
+			expectedPatch:      domain.FarmPatch{Name: testutils.PointerTo("Renamed Farm")},
+			mockResponse:       farm,
+			mockRequired:       true,
+		},
+		{
+			name:               "Bad Request - Invalid land area",
+			farmId:             farm.ID.String(),
+			payload:            `{"land_area": -10}`,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Successful unit measure update with an alias",
+			farmId:             farm.ID.String(),
+			payload:            `{"unit_measure": "ha"}`,
+			expectedStatusCode: fiber.StatusOK,
+			expectedPatch:      domain.FarmPatch{UnitMeasure: testutils.PointerTo(domain.UnitMeasureHectares)},
+			mockResponse:       farm,
+			mockRequired:       true,
+		},
+		{
+			name:               "Bad Request - Invalid unit measure",
+			farmId:             farm.ID.String(),
+			payload:            `{"unit_measure": "feet"}`,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Bad Request - Invalid crop type",
+			farmId:             farm.ID.String(),
+			payload:            `{"crop_productions": [{"crop_type": "InvalidType"}]}`,
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Not Found - Farm does not exist",
+			farmId:             farm.ID.String(),
+			payload:            `{"address": "New Address"}`,
+			expectedStatusCode: fiber.StatusNotFound,
+			expectedPatch:      domain.FarmPatch{Address: testutils.PointerTo("New Address")},
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farm.ID.String()},
+			mockRequired:       true,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockPatchFarmUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockPatchFarmUseCase)
+				mockUseCase.On("Execute", mock.Anything, tt.farmId, tt.expectedPatch).
+					Return(tt.mockResponse, tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Patch("/farms/:id", controller.PatchFarm)
+
+			req, err := http.NewRequest("PATCH", fmt.Sprintf("/farms/%s", tt.farmId), bytes.NewReader([]byte(tt.payload)))
+			assert.NoError(cs.T(), err)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerRestoreFarm() {
+	farm := testutils.GenerateFakeFarm(nil, nil)
+	tests := []struct {
+		name               string
+		farmId             string
+		expectedStatusCode int
+		mockResponse       *domain.Farm
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful farm restore",
+			farmId:             farm.ID.String(),
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse:       farm,
+			mockRequired:       true,
+		},
+		{
+			name:               "Deleted farm not found",
+			farmId:             farm.ID.String(),
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farm.ID.String()},
+			mockRequired:       true,
+		},
+		{
+			name:               "Malformed farm id",
+			farmId:             "invalid_id",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockRestoreFarmUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockRestoreFarmUseCase)
+				mockUseCase.On("Execute", mock.Anything, tt.farmId).Return(tt.mockResponse, tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Post("/farms/:id/restore", controller.RestoreFarm)
+			req, err := http.NewRequest("POST", fmt.Sprintf("/farms/%s/restore", tt.farmId), nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var responseFarm domain.Farm
+				err = json.NewDecoder(resp.Body).Decode(&responseFarm)
+				assert.NoError(cs.T(), err)
+				assert.Equal(cs.T(), tt.mockResponse.ID, responseFarm.ID)
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerPurgeFarm() {
+	farmId := uuid.New().String()
+	tests := []struct {
+		name               string
+		expectedStatusCode int
+		mockError          error
+	}{
+		{
+			name:               "Successful farm purge",
+			expectedStatusCode: fiber.StatusNoContent,
+		},
+		{
+			name:               "Farm not found",
+			expectedStatusCode: fiber.StatusNotFound,
+			mockError:          &shared.NotFoundError{Resource: "Farm", ID: farmId},
+		},
+		{
+			name:               "Unknown exception in use case layer",
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockError:          errors.New("Unknown error"),
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			mockUseCase := new(MockPurgeFarmUseCase)
+			mockUseCase.On("Execute", mock.Anything, farmId).Return(tt.mockError)
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, mockUseCase, nil, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Delete("/farms/:id/purge", controller.PurgeFarm)
+			req, err := http.NewRequest("DELETE", fmt.Sprintf("/farms/%s/purge", farmId), nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			mockUseCase.AssertExpectations(cs.T())
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerGetFarmStats() {
+	tests := []struct {
+		name               string
+		queryString        string
+		expectedStatusCode int
+		mockResponse       *domain.FarmStats
+		mockError          error
+		mockRequired       bool
+	}{
+		{
+			name:               "Successful statistics retrieval converted to acres",
+			queryString:        "?crop_type=CORN&unit=acres",
+			expectedStatusCode: fiber.StatusOK,
+			mockResponse: &domain.FarmStats{
+				FarmCount:       2,
+				LandAreaUnit:    domain.UnitMeasureHectares,
+				TotalLandArea:   domain.UnitMeasureAcres.ToHectares(300),
+				AverageLandArea: domain.UnitMeasureAcres.ToHectares(150),
+				CropTypes: []domain.CropTypeStats{
+					{CropType: domain.CropTypeCorn.String(), CropProductionCount: 2, FarmCount: 2, IrrigatedRatio: 0.5, InsuredRatio: 1},
+				},
+			},
+			mockRequired: true,
+		},
+		{
+			name:               "Invalid filter",
+			queryString:        "?is_irrigated=sometimes",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+		{
+			name:               "Unknown exception in use case layer",
+			queryString:        "",
+			expectedStatusCode: fiber.StatusInternalServerError,
+			mockError:          errors.New("Unknown error"),
+			mockRequired:       true,
+		},
+	}
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			mockUseCase := new(MockGetFarmStatsUseCase)
+			if tt.mockRequired {
+				mockUseCase.On("Execute", mock.Anything, mock.AnythingOfType("*domain.FarmSearchParameters")).
+					Return(tt.mockResponse, tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, nil, nil, cs.logger)
+			app := fiber.New()
+			app.Get("/farms/stats", controller.GetFarmStats)
+			req, err := http.NewRequest("GET", "/farms/stats"+tt.queryString, nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var stats domain.FarmStats
+				err = json.NewDecoder(resp.Body).Decode(&stats)
+				assert.NoError(cs.T(), err)
+				assert.Equal(cs.T(), int64(2), stats.FarmCount)
+				assert.Equal(cs.T(), domain.UnitMeasureAcres, stats.LandAreaUnit)
+				assert.InDelta(cs.T(), 300, stats.TotalLandArea, 1e-9)
+				assert.InDelta(cs.T(), 150, stats.AverageLandArea, 1e-9)
+				assert.Equal(cs.T(), tt.mockResponse.CropTypes, stats.CropTypes)
+			}
+			mockUseCase.AssertExpectations(cs.T())
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerListFarmsByCursor() {
+	cursor := domain.FarmCursor{CreatedAt: time.Now().UTC(), ID: uuid.New()}
+	nextCursor := domain.FarmCursor{CreatedAt: time.Now().UTC(), ID: uuid.New()}.Encode()
+	tests := []struct {
+		name               string
+		queryString        string
+		expectedStatusCode int
+		expectedLimit      int
+		expectedCursor     *domain.FarmCursor
+		mockRequired       bool
+	}{
+		{
+			name:               "First page with limit",
+			queryString:        "?limit=5",
+			expectedStatusCode: fiber.StatusOK,
+			expectedLimit:      5,
+			mockRequired:       true,
+		},
+		{
+			name:               "Next page with cursor",
+			queryString:        "?cursor=" + cursor.Encode(),
+			expectedStatusCode: fiber.StatusOK,
+			expectedLimit:      10,
+			expectedCursor:     &cursor,
+			mockRequired:       true,
+		},
+		{
+			name:               "Invalid cursor",
+			queryString:        "?cursor=not-a-cursor",
+			expectedStatusCode: fiber.StatusBadRequest,
+			mockRequired:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			var mockUseCase *MockListFarmsByCursorUseCase
+			if tt.mockRequired {
+				mockUseCase = new(MockListFarmsByCursorUseCase)
+				mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(params *domain.FarmSearchParameters) bool {
+					if tt.expectedCursor == nil {
+						return params.Limit == tt.expectedLimit && params.Cursor == nil
+					}
+					return params.Limit == tt.expectedLimit &&
+						params.Cursor != nil &&
+						params.Cursor.ID == tt.expectedCursor.ID &&
+						params.Cursor.CreatedAt.Equal(tt.expectedCursor.CreatedAt)
+				})).Return(&models.CursorPaginatedResponse[*domain.Farm]{
+					Items:      testutils.GenerateFarms(2, nil, nil),
+					NextCursor: &nextCursor,
+					Limit:      tt.expectedLimit,
+				}, nil)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, nil, nil, nil, cs.logger)
+			app := fiber.New(fiber.Config{
+				AppName:       "farm-api-test by @arthurgavazza",
+				CaseSensitive: true,
+			})
+			app.Get("/farms", controller.ListFarms)
+			req, err := http.NewRequest("GET", "/farms"+tt.queryString, nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var response models.CursorPaginatedResponse[*domain.Farm]
+				err = json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(cs.T(), err)
+				assert.Len(cs.T(), response.Items, 2)
+				assert.Equal(cs.T(), nextCursor, *response.NextCursor)
+			}
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(cs.T())
+			}
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerImportFarms() {
+	validCSV := "name,land_area,unit_measure,address,crop_type,is_irrigated,is_insured\n" +
+		"Farm A,10,hectares,Street A,RICE,true,false\n" +
+		"Farm A,10,hectares,Street A,CORN,false,true\n" +
+		"Farm B,5,acres,Street B,,,\n"
+	identicalFarmsCSV := "name,land_area,unit_measure,address,crop_type,is_irrigated,is_insured\n" +
+		"Farm A,10,hectares,Street A,RICE,true,false\n" +
+		"Farm B,5,acres,Street B,,,\n" +
+		"Farm A,10,hectares,Street A,CORN,false,true\n"
+	farmKeyCSV := "farm_key,name,land_area,unit_measure,address,crop_type,is_irrigated,is_insured\n" +
+		"1,Farm A,10,hectares,Street A,RICE,true,false\n" +
+		"2,Farm A,10,hectares,Street A,,,\n" +
+		"1,Farm A,10,hectares,Street A,CORN,false,true\n"
+	invalidCSV := "name,land_area,unit_measure,address,crop_type,is_irrigated,is_insured\n" +
+		"Farm A,10,hectares,Street A,RICE,true,false\n" +
+		"Farm B,ten,acres,Street B,,,\n" +
+		"Farm C,5,furlongs,Street C,WHEAT,false,false\n"
+	validNDJSON := `{"name":"Farm A","land_area":10,"unit_measure":"hectares","address":"Street A","crop_productions":[{"crop_type":"RICE"}]}` + "\n\n" +
+		`{"name":"Farm B","land_area":5,"unit_measure":"acres","address":"Street B"}` + "\n"
+	invalidNDJSON := `{"name":"Farm A","land_area":10,"unit_measure":"hectares","address":"Street A"}` + "\n" +
+		`{"name":` + "\n" +
+		`{"name":"Farm C","land_area":0,"unit_measure":"hectares","address":"Street C"}` + "\n"
+
+	tests := []struct {
+		name               string
+		queryString        string
+		contentType        string
+		body               string
+		expectedStatusCode int
+		expectedFarms      []string
+		expectedCrops      []int
+		expectedStatuses   map[int]string
+		expectedAtomic     bool
+		failedFarms        map[int]bool
+		mockError          error
+	}{
+		{
+			name:               "Consecutive CSV rows grouped by farm",
+			contentType:        "text/csv",
+			body:               validCSV,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A", "Farm B"},
+			expectedCrops:      []int{2, 0},
+			expectedStatuses:   map[int]string{2: models.FarmImportRowImported, 3: models.FarmImportRowImported, 4: models.FarmImportRowImported},
+			expectedAtomic:     true,
+		},
+		{
+			name:               "Identical farms with non consecutive CSV rows kept apart",
+			contentType:        "text/csv",
+			body:               identicalFarmsCSV,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A", "Farm B", "Farm A"},
+			expectedCrops:      []int{1, 0, 1},
+			expectedStatuses:   map[int]string{2: models.FarmImportRowImported, 3: models.FarmImportRowImported, 4: models.FarmImportRowImported},
+			expectedAtomic:     true,
+		},
+		{
+			name:               "CSV rows grouped by farm key",
+			contentType:        "text/csv",
+			body:               farmKeyCSV,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A", "Farm A"},
+			expectedCrops:      []int{2, 0},
+			expectedStatuses:   map[int]string{2: models.FarmImportRowImported, 3: models.FarmImportRowImported, 4: models.FarmImportRowImported},
+			expectedAtomic:     true,
+		},
+		{
+			name:               "Farms that could not be created reported as failed",
+			queryString:        "?mode=skip_invalid",
+			contentType:        "text/csv",
+			body:               validCSV,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A", "Farm B"},
+			expectedCrops:      []int{2, 0},
+			expectedStatuses:   map[int]string{2: models.FarmImportRowImported, 3: models.FarmImportRowImported, 4: models.FarmImportRowFailed},
+			failedFarms:        map[int]bool{1: true},
+		},
+		{
+			name:               "NDJSON with a blank line",
+			queryString:        "?format=ndjson",
+			contentType:        "text/plain",
+			body:               validNDJSON,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A", "Farm B"},
+			expectedCrops:      []int{1, 0},
+			expectedStatuses:   map[int]string{1: models.FarmImportRowImported, 3: models.FarmImportRowImported},
+			expectedAtomic:     true,
+		},
+		{
+			name:               "Invalid CSV rows reject the whole import",
+			contentType:        "text/csv",
+			body:               invalidCSV,
+			expectedStatusCode: fiber.StatusUnprocessableEntity,
+			expectedStatuses:   map[int]string{2: models.FarmImportRowRejected, 3: models.FarmImportRowInvalid, 4: models.FarmImportRowInvalid},
+		},
+		{
+			name:               "Invalid CSV rows skipped",
+			queryString:        "?mode=skip_invalid",
+			contentType:        "text/csv",
+			body:               invalidCSV,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A"},
+			expectedCrops:      []int{1},
+			expectedStatuses:   map[int]string{2: models.FarmImportRowImported, 3: models.FarmImportRowInvalid, 4: models.FarmImportRowInvalid},
+		},
+		{
+			name:               "Invalid NDJSON lines skipped",
+			queryString:        "?mode=skip_invalid",
+			contentType:        "application/x-ndjson",
+			body:               invalidNDJSON,
+			expectedStatusCode: fiber.StatusOK,
+			expectedFarms:      []string{"Farm A"},
+			expectedCrops:      []int{0},
+			expectedStatuses:   map[int]string{1: models.FarmImportRowImported, 2: models.FarmImportRowInvalid, 3: models.FarmImportRowInvalid},
+		},
+		{
+			name:               "CSV header without required columns",
+			contentType:        "text/csv",
+			body:               "name,land_area\nFarm A,10\n",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Unknown format",
+			contentType:        "application/json",
+			body:               validNDJSON,
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Invalid mode",
+			queryString:        "?mode=some",
+			contentType:        "text/csv",
+			body:               validCSV,
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Unknown exception in use case layer",
+			contentType:        "text/csv",
+			body:               validCSV,
+			expectedStatusCode: fiber.StatusInternalServerError,
+			expectedFarms:      []string{"Farm A", "Farm B"},
+			expectedCrops:      []int{2, 0},
+			expectedAtomic:     true,
+			mockError:          errors.New("Unknown error"),
+		},
+	}
+
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			mockUseCase := new(MockImportFarmsUseCase)
+			var importedFarms []*domain.Farm
+			var importErrors []error
+			for i, name := range tt.expectedFarms {
+				if tt.failedFarms[i] {
+					importedFarms = append(importedFarms, nil)
+					importErrors = append(importErrors, errors.New("database error"))
+					continue
+				}
+				importedFarms = append(importedFarms, &domain.Farm{ID: uuid.New(), Name: name})
+				importErrors = append(importErrors, nil)
+			}
+			if tt.expectedFarms != nil {
+				call := mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(farms []domain.Farm) bool {
+					if len(farms) != len(tt.expectedFarms) {
+						return false
+					}
+					for i, farm := range farms {
+						if farm.Name != tt.expectedFarms[i] || len(farm.CropProductions) != tt.expectedCrops[i] {
+							return false
+						}
+					}
+					return true
+				}), tt.expectedAtomic)
+				if tt.mockError != nil {
+					call.Return(nil, nil, tt.mockError)
+				} else {
+					call.Return(importedFarms, importErrors, nil)
+				}
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, nil, cs.logger)
+			app := fiber.New()
+			app.Post("/farms/import", controller.ImportFarms)
+			req, err := http.NewRequest("POST", "/farms/import"+tt.queryString, bytes.NewBufferString(tt.body))
+			assert.NoError(cs.T(), err)
+			req.Header.Set("Content-Type", tt.contentType)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatuses != nil {
+				var report models.FarmImportReport
+				err = json.NewDecoder(resp.Body).Decode(&report)
+				assert.NoError(cs.T(), err)
+				assert.Len(cs.T(), report.Rows, len(tt.expectedStatuses))
+				for _, row := range report.Rows {
+					assert.Equal(cs.T(), tt.expectedStatuses[row.Line], row.Status, "line %d", row.Line)
+					if row.Status == models.FarmImportRowInvalid || row.Status == models.FarmImportRowFailed {
+						assert.NotEmpty(cs.T(), row.Errors)
+					}
+					if row.Status == models.FarmImportRowImported {
+						assert.NotNil(cs.T(), row.FarmID)
+					}
+				}
+				if tt.expectedStatusCode == fiber.StatusOK {
+					assert.Equal(cs.T(), len(tt.expectedFarms)-len(tt.failedFarms), report.ImportedFarms)
+					assert.Equal(cs.T(), len(tt.failedFarms), report.FailedFarms)
+					assert.Equal(cs.T(), *report.Rows[0].FarmID, importedFarms[0].ID)
+				}
+			}
+			mockUseCase.AssertExpectations(cs.T())
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerImportFarmsMultipartUpload() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "farms.ndjson")
+	assert.NoError(cs.T(), err)
+	_, err = part.Write([]byte(`{"name":"Farm A","land_area":10,"unit_measure":"ha","address":"Street A"}` + "\n"))
+	assert.NoError(cs.T(), err)
+	assert.NoError(cs.T(), writer.Close())
+
+	mockUseCase := new(MockImportFarmsUseCase)
+	mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(farms []domain.Farm) bool {
+		return len(farms) == 1 && farms[0].UnitMeasure == domain.UnitMeasureHectares
+	}), true).Return([]*domain.Farm{{ID: uuid.New(), Name: "Farm A"}}, []error{nil}, nil)
+
+	controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, nil, cs.logger)
+	app := fiber.New()
+	app.Post("/farms/import", controller.ImportFarms)
+	req, err := http.NewRequest("POST", "/farms/import", body)
+	assert.NoError(cs.T(), err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusOK, resp.StatusCode)
+	var report models.FarmImportReport
+	assert.NoError(cs.T(), json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(cs.T(), "ndjson", report.Format)
+	assert.Equal(cs.T(), 1, report.ImportedFarms)
+	mockUseCase.AssertExpectations(cs.T())
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerExportFarms() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	farmWithCrops := &domain.Farm{
+		ID:          uuid.New(),
+		Name:        "Farm A",
+		LandArea:    10,
+		UnitMeasure: domain.UnitMeasureHectares,
+		Address:     "Street A",
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		CropProductions: []domain.CropProduction{
+			{ID: uuid.New(), CropType: "RICE", IsIrrigated: true},
+			{ID: uuid.New(), CropType: "CORN", IsInsured: true},
+		},
+	}
+	farmWithoutCrops := &domain.Farm{
+		ID:              uuid.New(),
+		Name:            "Farm, B",
+		LandArea:        2.5,
+		UnitMeasure:     domain.UnitMeasureAcres,
+		Address:         "Street B",
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+		CropProductions: []domain.CropProduction{},
+	}
+	header := "id,name,land_area,unit_measure,address,created_at,updated_at,deleted_at,crop_production_id,crop_type,is_irrigated,is_insured\n"
+
+	tests := []struct {
+		name                string
+		queryString         string
+		batches             [][]*domain.Farm
+		mockError           error
+		mockRequired        bool
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "CSV with one row per crop production",
+			queryString:         "?crop_type=RICE,CORN",
+			batches:             [][]*domain.Farm{{farmWithCrops}, {farmWithoutCrops}},
+			mockRequired:        true,
+			expectedStatusCode:  fiber.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: header +
+				fmt.Sprintf("%s,Farm A,10,hectares,Street A,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,%s,RICE,true,false\n", farmWithCrops.ID, farmWithCrops.CropProductions[0].ID) +
+				fmt.Sprintf("%s,Farm A,10,hectares,Street A,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,%s,CORN,false,true\n", farmWithCrops.ID, farmWithCrops.CropProductions[1].ID) +
+				fmt.Sprintf("%s,\"Farm, B\",2.5,acres,Street B,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z,,,,,\n", farmWithoutCrops.ID),
+		},
+		{
+			name:                "CSV without matching farms",
+			mockRequired:        true,
+			expectedStatusCode:  fiber.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        header,
+		},
+		{
+			name:                "NDJSON with one farm per line",
+			queryString:         "?format=ndjson",
+			batches:             [][]*domain.Farm{{farmWithCrops, farmWithoutCrops}},
+			mockRequired:        true,
+			expectedStatusCode:  fiber.StatusOK,
+			expectedContentType: "application/x-ndjson",
+		},
+		{
+			name:               "Invalid format",
+			queryString:        "?format=xml",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Invalid filter",
+			queryString:        "?is_insured=maybe",
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Unknown exception before streaming",
+			mockRequired:       true,
+			mockError:          errors.New("Unknown error"),
+			expectedStatusCode: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			mockUseCase := &MockExportFarmsUseCase{batches: tt.batches}
+			if tt.mockRequired {
+				mockUseCase.On("Execute", mock.Anything, mock.AnythingOfType("*domain.FarmSearchParameters"), farmExportBatchSize).
+					Return(tt.mockError)
+			}
+
+			controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, cs.logger)
+			app := fiber.New()
+			app.Get("/farms/export", controller.ExportFarms)
+			req, err := http.NewRequest("GET", "/farms/export"+tt.queryString, nil)
+			assert.NoError(cs.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				assert.Equal(cs.T(), tt.expectedContentType, resp.Header.Get("Content-Type"))
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(cs.T(), err)
+				if tt.expectedBody != "" {
+					assert.Equal(cs.T(), tt.expectedBody, string(body))
+				} else {
+					lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+					assert.Len(cs.T(), lines, 2)
+					var farm domain.Farm
+					assert.NoError(cs.T(), json.Unmarshal([]byte(lines[0]), &farm))
+					assert.Equal(cs.T(), farmWithCrops.ID, farm.ID)
+					assert.Len(cs.T(), farm.CropProductions, 2)
+				}
+			}
+			mockUseCase.AssertExpectations(cs.T())
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerExportFarmsImportedBack() {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	farms := []*domain.Farm{
+		{
+			ID: uuid.New(), Name: "Farm A", LandArea: 10.25, UnitMeasure: domain.UnitMeasureHectares, Address: "Street A",
+			CreatedAt: createdAt, UpdatedAt: createdAt,
+			CropProductions: []domain.CropProduction{
+				{ID: uuid.New(), CropType: "RICE", IsIrrigated: true},
+				{ID: uuid.New(), CropType: "CORN", IsInsured: true},
+			},
+		},
+		// identical farms exported one after the other stay apart
+		{ID: uuid.New(), Name: "Farm, B", LandArea: 2.5, UnitMeasure: domain.UnitMeasureAcres, Address: "Street B", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: uuid.New(), Name: "Farm, B", LandArea: 2.5, UnitMeasure: domain.UnitMeasureAcres, Address: "Street B", CreatedAt: createdAt, UpdatedAt: createdAt, DeletedAt: &createdAt},
+		// the cells a spreadsheet would run as formulas are escaped, and unescaped by the import
+		{ID: uuid.New(), Name: `=HYPERLINK("http://example.com")`, LandArea: 3, UnitMeasure: domain.UnitMeasureAcres, Address: "@SUM(A1)", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: uuid.New(), Name: "'=quoted", LandArea: 3, UnitMeasure: domain.UnitMeasureAcres, Address: "'Tis lane", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+	exported := &bytes.Buffer{}
+	exportWriter := newFarmExportWriter(farmImportFormatCSV, exported)
+	assert.NoError(cs.T(), exportWriter.WriteFarms(farms))
+	assert.NoError(cs.T(), exportWriter.Flush())
+	assert.Contains(cs.T(), exported.String(), `"'=HYPERLINK(""http://example.com"")"`)
+	assert.Contains(cs.T(), exported.String(), ",'@SUM(A1),")
+	assert.Contains(cs.T(), exported.String(), ",''=quoted,")
+
+	records, err := parseFarmImportCSV(exported)
+	assert.NoError(cs.T(), err)
+	assert.Len(cs.T(), records, len(farms))
+	for i, record := range records {
+		assert.True(cs.T(), record.isValid())
+		if errs := record.farm.Validate(); len(errs) > 0 {
+			assert.False(cs.T(), errs[0].Error)
+		}
+		imported := toDomainFarm(record.farm)
+		assert.Equal(cs.T(), farms[i].Name, imported.Name)
+		assert.Equal(cs.T(), farms[i].LandArea, imported.LandArea)
+		assert.Equal(cs.T(), farms[i].UnitMeasure, imported.UnitMeasure)
+		assert.Equal(cs.T(), farms[i].Address, imported.Address)
+		assert.Len(cs.T(), imported.CropProductions, len(farms[i].CropProductions))
+		for j, production := range imported.CropProductions {
+			assert.Equal(cs.T(), farms[i].CropProductions[j].CropType, production.CropType)
+			assert.Equal(cs.T(), farms[i].CropProductions[j].IsIrrigated, production.IsIrrigated)
+			assert.Equal(cs.T(), farms[i].CropProductions[j].IsInsured, production.IsInsured)
+		}
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerExportFarmsCancelledOnceStreamed() {
+	var exportCtx context.Context
+	mockUseCase := &MockExportFarmsUseCase{batches: [][]*domain.Farm{{{ID: uuid.New(), Name: "Farm A", UnitMeasure: domain.UnitMeasureHectares}}}}
+	mockUseCase.On("Execute", mock.Anything, mock.AnythingOfType("*domain.FarmSearchParameters"), farmExportBatchSize).
+		Run(func(args mock.Arguments) {
+			exportCtx = args.Get(0).(context.Context)
+		}).
+		Return(nil)
+
+	controller := NewFarmController(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, mockUseCase, cs.logger)
+	app := fiber.New()
+	app.Get("/farms/export", controller.ExportFarms)
+	req, err := http.NewRequest("GET", "/farms/export", nil)
+	assert.NoError(cs.T(), err)
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+	_, err = io.ReadAll(resp.Body)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusOK, resp.StatusCode)
+	assert.Eventually(cs.T(), func() bool {
+		return exportCtx.Err() != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (cs *FarmControllerTestSuite) TestFarmExportStreamCloseCancelsTheExport() {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	stream := &farmExportStream{PipeReader: reader, cancel: cancel}
+
+	assert.NoError(cs.T(), stream.Close())
+
+	assert.ErrorIs(cs.T(), ctx.Err(), context.Canceled)
+	_, err := writer.Write([]byte("farm"))
+	assert.ErrorIs(cs.T(), err, io.ErrClosedPipe)
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerForbidden() {
+	farmId := uuid.New().String()
+	forbidden := func(permission domain.Permission) error {
+		return &shared.ForbiddenError{Permission: string(permission)}
+	}
+	createUseCase := new(MockCreateFarmUseCase)
+	createUseCase.On("Execute", mock.Anything, mock.Anything).Return((*domain.Farm)(nil), forbidden(domain.PermissionCreateFarms))
+	listUseCase := new(MockListFarmsUseCase)
+	listUseCase.On("Execute", mock.Anything, mock.Anything).Return((*models.PaginatedResponse[*domain.Farm])(nil), forbidden(domain.PermissionReadFarms))
+	deleteUseCase := new(MockDeleteFarmUseCase)
+	deleteUseCase.On("Execute", mock.Anything, farmId).Return(forbidden(domain.PermissionDeleteFarms))
+	getUseCase := new(MockGetFarmUseCase)
+	getUseCase.On("Execute", mock.Anything, farmId).Return((*domain.Farm)(nil), forbidden(domain.PermissionReadFarms))
+	updateUseCase := new(MockUpdateFarmUseCase)
+	updateUseCase.On("Execute", mock.Anything, mock.Anything).Return((*domain.Farm)(nil), forbidden(domain.PermissionUpdateFarms))
+	patchUseCase := new(MockPatchFarmUseCase)
+	patchUseCase.On("Execute", mock.Anything, farmId, mock.Anything).Return((*domain.Farm)(nil), forbidden(domain.PermissionUpdateFarms))
+	restoreUseCase := new(MockRestoreFarmUseCase)
+	restoreUseCase.On("Execute", mock.Anything, farmId).Return((*domain.Farm)(nil), forbidden(domain.PermissionRestoreFarms))
+	purgeUseCase := new(MockPurgeFarmUseCase)
+	purgeUseCase.On("Execute", mock.Anything, farmId).Return(forbidden(domain.PermissionPurgeFarms))
+	listByCursorUseCase := new(MockListFarmsByCursorUseCase)
+	listByCursorUseCase.On("Execute", mock.Anything, mock.Anything).Return((*models.CursorPaginatedResponse[*domain.Farm])(nil), forbidden(domain.PermissionReadFarms))
+	statsUseCase := new(MockGetFarmStatsUseCase)
+	statsUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, forbidden(domain.PermissionReadFarms))
+	importUseCase := new(MockImportFarmsUseCase)
+	importUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, forbidden(domain.PermissionCreateFarms))
+	exportUseCase := new(MockExportFarmsUseCase)
+	exportUseCase.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(forbidden(domain.PermissionReadFarms))
+
+	controller := NewFarmController(createUseCase, listUseCase, deleteUseCase, getUseCase, updateUseCase, patchUseCase,
+		restoreUseCase, purgeUseCase, listByCursorUseCase, statsUseCase, importUseCase, exportUseCase, cs.logger)
+	app := fiber.New()
+	app.Post("/farms", controller.CreateFarm)
+	app.Get("/farms", controller.ListFarms)
+	app.Get("/farms/stats", controller.GetFarmStats)
+	app.Get("/farms/export", controller.ExportFarms)
+	app.Post("/farms/import", controller.ImportFarms)
+	app.Get("/farms/:id", controller.GetFarm)
+	app.Put("/farms/:id", controller.UpdateFarm)
+	app.Patch("/farms/:id", controller.PatchFarm)
+	app.Delete("/farms/:id", controller.DeleteFarm)
+	app.Post("/farms/:id/restore", controller.RestoreFarm)
+	app.Delete("/farms/:id/purge", controller.PurgeFarm)
+
+	validFarm := `{"name":"Farm A","land_area":10,"unit_measure":"hectares","address":"Street A"}`
+	tests := []struct {
+		name               string
+		method             string
+		route              string
+		contentType        string
+		body               string
+		expectedPermission domain.Permission
+	}{
+		{name: "Create farm", method: "POST", route: "/farms", contentType: "application/json", body: validFarm, expectedPermission: domain.PermissionCreateFarms},
+		{name: "List farms", method: "GET", route: "/farms", expectedPermission: domain.PermissionReadFarms},
+		{name: "List farms by cursor", method: "GET", route: "/farms?cursor=", expectedPermission: domain.PermissionReadFarms},
+		{name: "Farm stats", method: "GET", route: "/farms/stats", expectedPermission: domain.PermissionReadFarms},
+		{name: "Export farms", method: "GET", route: "/farms/export", expectedPermission: domain.PermissionReadFarms},
+		{name: "Import farms", method: "POST", route: "/farms/import?format=ndjson", contentType: "application/x-ndjson", body: validFarm + "\n", expectedPermission: domain.PermissionCreateFarms},
+		{name: "Get farm", method: "GET", route: "/farms/" + farmId, expectedPermission: domain.PermissionReadFarms},
+		{name: "Update farm", method: "PUT", route: "/farms/" + farmId, contentType: "application/json", body: validFarm, expectedPermission: domain.PermissionUpdateFarms},
+		{name: "Patch farm", method: "PATCH", route: "/farms/" + farmId, contentType: "application/json", body: `{"name":"Farm B"}`, expectedPermission: domain.PermissionUpdateFarms},
+		{name: "Delete farm", method: "DELETE", route: "/farms/" + farmId, expectedPermission: domain.PermissionDeleteFarms},
+		{name: "Restore farm", method: "POST", route: "/farms/" + farmId + "/restore", expectedPermission: domain.PermissionRestoreFarms},
+		{name: "Purge farm", method: "DELETE", route: "/farms/" + farmId + "/purge", expectedPermission: domain.PermissionPurgeFarms},
+	}
+
+	for _, tt := range tests {
+		cs.Run(tt.name, func() {
+			req, err := http.NewRequest(tt.method, tt.route, strings.NewReader(tt.body))
+			assert.NoError(cs.T(), err)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(cs.T(), err)
+
+			assert.Equal(cs.T(), fiber.StatusForbidden, resp.StatusCode)
+			var response shared.CustomError
+			assert.NoError(cs.T(), json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(cs.T(), fmt.Sprintf("permission %s is required", tt.expectedPermission), response.Error)
+		})
+	}
+}
+
+func (cs *FarmControllerTestSuite) TestFarmControllerMissingOrganization() {
+	mockUseCase := new(MockListFarmsUseCase)
+	mockUseCase.On("Execute", mock.Anything, mock.Anything).Return((*models.PaginatedResponse[*domain.Farm])(nil), domain.ErrMissingOrganization)
+	controller := NewFarmController(nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cs.logger)
+	app := fiber.New()
+	app.Get("/farms", controller.ListFarms)
+
+	req, err := http.NewRequest("GET", "/farms", nil)
+	assert.NoError(cs.T(), err)
+	resp, err := app.Test(req)
+	assert.NoError(cs.T(), err)
+
+	assert.Equal(cs.T(), fiber.StatusForbidden, resp.StatusCode)
+	var response shared.CustomError
+	assert.NoError(cs.T(), json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(cs.T(), domain.ErrMissingOrganization.Error(), response.Error)
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(FarmControllerTestSuite))
 }
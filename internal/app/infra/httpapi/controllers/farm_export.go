@@ -0,0 +1,285 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer delegates to the global tracer provider, it traces the work that outlives the request span
+var tracer = otel.Tracer("github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers")
+
+// farmExportBatchSize is the number of farms read from the database at a time while exporting
+const farmExportBatchSize = 500
+
+// farmExportCSVHeader lists the farm export CSV columns. The exported files can be imported back: the import groups the rows
+// by their id column and reads the farm and crop columns, the other columns are ignored and the farms are created anew
+var farmExportCSVHeader = []string{
+	"id",
+	"name",
+	"land_area",
+	"unit_measure",
+	"address",
+	"created_at",
+	"updated_at",
+	"deleted_at",
+	"crop_production_id",
+	"crop_type",
+	"is_irrigated",
+	"is_insured",
+}
+
+// csvFormulaPrefixes are the first characters that make a spreadsheet read a cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// escapeCSVFormula prefixes the user supplied cells that a spreadsheet would run as a formula with a quote, which the
+// spreadsheets hide. Cells already starting with a quote are prefixed as well so unescapeCSVFormula restores them.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVFormula removes the quote added by escapeCSVFormula, other cells starting with a quote are kept as is
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes+"'", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+type farmExportWriter interface {
+	WriteFarms(farms []*domain.Farm) error
+	Flush() error
+}
+
+// csvFarmExportWriter writes one row per farm and crop production pair, farms without crop productions get a single row with empty crop columns.
+// The farm name and address are escaped so they are not run as formulas when the file is opened in a spreadsheet.
+type csvFarmExportWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvFarmExportWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(farmExportCSVHeader)
+}
+
+func (w *csvFarmExportWriter) WriteFarms(farms []*domain.Farm) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for _, farm := range farms {
+		deletedAt := ""
+		if farm.DeletedAt != nil {
+			deletedAt = farm.DeletedAt.UTC().Format(time.RFC3339)
+		}
+		farmColumns := []string{
+			farm.ID.String(),
+			escapeCSVFormula(farm.Name),
+			strconv.FormatFloat(farm.LandArea, 'f', -1, 64),
+			farm.UnitMeasure.String(),
+			escapeCSVFormula(farm.Address),
+			farm.CreatedAt.UTC().Format(time.RFC3339),
+			farm.UpdatedAt.UTC().Format(time.RFC3339),
+			deletedAt,
+		}
+		if len(farm.CropProductions) == 0 {
+			if err := w.writer.Write(append(farmColumns, "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, production := range farm.CropProductions {
+			row := append(append([]string{}, farmColumns...),
+				production.ID.String(),
+				string(production.CropType),
+				strconv.FormatBool(production.IsIrrigated),
+				strconv.FormatBool(production.IsInsured),
+			)
+			if err := w.writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvFarmExportWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// ndjsonFarmExportWriter writes one farm per line, with its crop productions nested like in the farms listing
+type ndjsonFarmExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonFarmExportWriter) WriteFarms(farms []*domain.Farm) error {
+	for _, farm := range farms {
+		if err := w.encoder.Encode(farm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ndjsonFarmExportWriter) Flush() error {
+	return nil
+}
+
+func newFarmExportWriter(format string, writer io.Writer) farmExportWriter {
+	if format == farmImportFormatNDJSON {
+		return &ndjsonFarmExportWriter{encoder: json.NewEncoder(writer)}
+	}
+	return &csvFarmExportWriter{writer: csv.NewWriter(writer)}
+}
+
+// @Summary Export farms
+// @Description Streams every farm matching the same filters as the farms listing, without pagination.
+// @Description The CSV format has one row per farm and crop production pair, the NDJSON format has one farm per line.
+// @Description The CSV files can be imported back as new farms, the ids, timestamps and deleted_at columns are ignored by the import.
+// @Tags Farm
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "Export file format" Enums(csv, ndjson) default(csv)
+// @Param name query string false "Case-insensitive farm name substring"
+// @Param address query string false "Case-insensitive farm address substring"
+// @Param crop_type query []string false "Crop types, repeated or comma separated" collectionFormat(multi)
+// @Param crop_type_match query string false "Whether farms must produce any or all of the crop types" Enums(any, all) default(any)
+// @Param is_irrigated query bool false "Only farms with an irrigated (or non irrigated) crop production"
+// @Param is_insured query bool false "Only farms with an insured (or non insured) crop production"
+// @Param unit_measure query string false "Unit Measure" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param minimum_land_area query float64 false "Minimum Land Area, in hectares unless unit is given"
+// @Param maximum_land_area query float64 false "Maximum Land Area, in hectares unless unit is given"
+// @Param unit query string false "Unit of the land area range and of the exported land areas" Enums(hectares, acres, square_meters, square_kilometers, alqueires_paulista, alqueires_mineiro)
+// @Param created_after query string false "Only farms created at or after this RFC 3339 timestamp or YYYY-MM-DD date"
+// @Param created_before query string false "Only farms created before this RFC 3339 timestamp, or on or before this YYYY-MM-DD date"
+// @Param include_deleted query bool false "Include deleted farms"
+// @Param only_deleted query bool false "Only export deleted farms"
+// @Success 200 {file} file "Farms Export"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/export [get]
+func (fc *FarmController) ExportFarms(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", farmImportFormatCSV))
+	if format != farmImportFormatCSV && format != farmImportFormatNDJSON {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: fmt.Sprintf("invalid format %q, must be one of: %s, %s", format, farmImportFormatCSV, farmImportFormatNDJSON),
+		})
+	}
+	searchParameters, err := parseFarmSearchFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+
+	// the export outlives the handler, so it can't rely on the fiber context once the response starts streaming.
+	// Its context is cancelled once the response stream is closed, when the client goes away or the export completes
+	requestCtx := requestContext(c)
+	ctx, cancel := context.WithCancel(domain.ContextWithPrincipal(
+		domain.ContextWithRoute(
+			domain.ContextWithRequestID(
+				trace.ContextWithSpan(context.Background(), trace.SpanFromContext(requestCtx)),
+				domain.RequestIDFromContext(requestCtx),
+			),
+			domain.RouteFromContext(requestCtx),
+		),
+		domain.PrincipalFromContext(requestCtx),
+	))
+	reader, writer := io.Pipe()
+	started := make(chan error, 1)
+	go func() {
+		defer cancel()
+		ctx, span := tracer.Start(ctx, "ExportFarms.Stream", trace.WithAttributes(attribute.String("export.format", format)))
+		defer span.End()
+		exportWriter := newFarmExportWriter(format, writer)
+		waiting := true
+		exported := 0
+		err := fc.exportFarmsUseCase.Execute(ctx, searchParameters, farmExportBatchSize, func(farms []*domain.Farm) error {
+			if waiting {
+				waiting = false
+				started <- nil
+			}
+			convertLandAreas(farms, searchParameters.LandAreaUnit)
+			if err := exportWriter.WriteFarms(farms); err != nil {
+				// the stream is closed, the remaining batches are not read
+				cancel()
+				return err
+			}
+			exported += len(farms)
+			return nil
+		})
+		if waiting {
+			// nothing was streamed yet, the handler can still answer with an error status
+			started <- err
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				writer.CloseWithError(err)
+				return
+			}
+		}
+		if err == nil {
+			err = exportWriter.Flush()
+		}
+		span.SetAttributes(attribute.Int("export.farms", exported))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			fc.logger.Error(ctx, "Farm export interrupted", err)
+		}
+		writer.CloseWithError(err)
+	}()
+
+	if err := <-started; err != nil {
+		return fc.farmErrorResponse(c, err)
+	}
+	contentType := "text/csv; charset=utf-8"
+	if format == farmImportFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="farms.%s"`, format))
+	return c.Status(fiber.StatusOK).SendStream(&farmExportStream{PipeReader: reader, cancel: cancel})
+}
+
+// farmExportStream is the response body of the farm export, closing it cancels the export
+type farmExportStream struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+func (s *farmExportStream) Close() error {
+	s.cancel()
+	return s.PipeReader.Close()
+}
+
+func (s *farmExportStream) CloseWithError(err error) error {
+	s.cancel()
+	return s.PipeReader.CloseWithError(err)
+}
@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/dto"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	farmImportModeAllOrNothing = "all_or_nothing"
+	farmImportModeSkipInvalid  = "skip_invalid"
+
+	farmImportFormatCSV    = "csv"
+	farmImportFormatNDJSON = "ndjson"
+
+	// farmImportMaxLineSize is the longest NDJSON line accepted by the import
+	farmImportMaxLineSize = 1024 * 1024
+)
+
+var farmImportCSVRequiredColumns = []string{"name", "land_area", "unit_measure", "address"}
+
+// farmImportCSVFarmKeyColumns are the optional CSV columns identifying the farm of each row, in order of precedence,
+// the id column of the exported files is used when there is no farm_key column. The rows with an empty key hold a farm each
+var farmImportCSVFarmKeyColumns = []string{"farm_key", "id"}
+
+// farmImportRecord is a farm read from an import file along with the lines it was read from
+type farmImportRecord struct {
+	farm       dto.CreateFarmDTO
+	lines      []int
+	lineErrors map[int][]string
+	errors     []string
+	farmID     *uuid.UUID
+	// key identifies the farm of the CSV rows without farm key, from their farm columns
+	key string
+	// failed is set when the valid farm could not be created
+	failed bool
+}
+
+func newFarmImportRecord() *farmImportRecord {
+	return &farmImportRecord{lineErrors: make(map[int][]string)}
+}
+
+func (r *farmImportRecord) isValid() bool {
+	if len(r.errors) > 0 {
+		return false
+	}
+	for _, errs := range r.lineErrors {
+		if len(errs) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFarmImportCSV reads a CSV file holding one row per farm and crop production pair. When the header has a farm key
+// column, the rows sharing the same farm key belong to the same farm, consecutive or not. Otherwise only consecutive rows
+// sharing the same name, land area, unit measure and address belong to the same farm, so identical farms are kept apart
+// when their rows are not consecutive. Rows without a crop_type hold a farm without crop productions
+func parseFarmImportCSV(reader io.Reader) ([]*farmImportRecord, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range farmImportCSVRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("the CSV header is missing the %q column", column)
+		}
+	}
+
+	farmKeyColumn := ""
+	for _, column := range farmImportCSVFarmKeyColumns {
+		if _, ok := columns[column]; ok {
+			farmKeyColumn = column
+			break
+		}
+	}
+	var records []*farmImportRecord
+	// recordsByKey holds the farms read so far by farm key, empty keys excluded
+	recordsByKey := make(map[string]*farmImportRecord)
+	for {
+		row, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		line, _ := csvReader.FieldPos(0)
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+
+		var rowErrors []string
+		landArea, err := strconv.ParseFloat(value("land_area"), 64)
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("[LandArea]: '%s' | Needs to be a number", value("land_area")))
+		}
+		isIrrigated, err := parseFarmImportBool(value("is_irrigated"))
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("[IsIrrigated]: '%s' | Needs to be a boolean", value("is_irrigated")))
+		}
+		isInsured, err := parseFarmImportBool(value("is_insured"))
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("[IsInsured]: '%s' | Needs to be a boolean", value("is_insured")))
+		}
+
+		var record *farmImportRecord
+		if farmKeyColumn != "" {
+			key := value(farmKeyColumn)
+			record = recordsByKey[key]
+			if record == nil {
+				record = newFarmImportCSVRecord(value, landArea)
+				records = append(records, record)
+				if key != "" {
+					recordsByKey[key] = record
+				}
+			}
+		} else {
+			key := strings.Join([]string{value("name"), value("land_area"), value("unit_measure"), value("address")}, "\x00")
+			if len(records) > 0 && records[len(records)-1].key == key {
+				record = records[len(records)-1]
+			} else {
+				record = newFarmImportCSVRecord(value, landArea)
+				record.key = key
+				records = append(records, record)
+			}
+		}
+		record.lines = append(record.lines, line)
+		record.lineErrors[line] = rowErrors
+		if cropType := value("crop_type"); cropType != "" {
+			record.farm.CropProductions = append(record.farm.CropProductions, dto.CropProductionDTO{
+				CropType:    cropType,
+				IsIrrigated: isIrrigated,
+				IsInsured:   isInsured,
+			})
+		}
+	}
+	return records, nil
+}
+
+func newFarmImportCSVRecord(value func(column string) string, landArea float64) *farmImportRecord {
+	record := newFarmImportRecord()
+	record.farm = dto.CreateFarmDTO{
+		Name:            unescapeCSVFormula(value("name")),
+		LandArea:        landArea,
+		UnitMeasure:     value("unit_measure"),
+		Address:         unescapeCSVFormula(value("address")),
+		CropProductions: []dto.CropProductionDTO{},
+	}
+	return record
+}
+
+func parseFarmImportBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// parseFarmImportNDJSON reads a file holding one farm creation JSON object per line, blank lines are ignored
+func parseFarmImportNDJSON(reader io.Reader) ([]*farmImportRecord, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), farmImportMaxLineSize)
+
+	var records []*farmImportRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		record := newFarmImportRecord()
+		record.lines = []int{line}
+		if err := json.Unmarshal(content, &record.farm); err != nil {
+			record.lineErrors[line] = []string{fmt.Sprintf("invalid JSON: %s", err.Error())}
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON file: %w", err)
+	}
+	return records, nil
+}
+
+// farmImportFormat detects the format of the import file from its name or content type
+func farmImportFormat(fileName string, contentType string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return farmImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return farmImportFormatNDJSON
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return farmImportFormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return farmImportFormatNDJSON
+	}
+	return ""
+}
+
+// farmImportFile returns the import file, either uploaded in the "file" field of a multipart form or sent as the request body,
+// and its format which can be forced with the format query parameter
+func farmImportFile(c *fiber.Ctx) (io.ReadCloser, string, error) {
+	format := strings.ToLower(c.Query("format"))
+	if format != "" && format != farmImportFormatCSV && format != farmImportFormatNDJSON {
+		return nil, "", fmt.Errorf("invalid format %q, must be one of: %s, %s", format, farmImportFormatCSV, farmImportFormatNDJSON)
+	}
+
+	if strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("the import file must be sent in the file field of the form")
+		}
+		if format == "" {
+			format = farmImportFormat(fileHeader.Filename, fileHeader.Header.Get(fiber.HeaderContentType))
+		}
+		if format == "" {
+			return nil, "", errors.New("unable to detect the import file format, use the format query parameter")
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		return file, format, nil
+	}
+
+	if format == "" {
+		format = farmImportFormat("", c.Get(fiber.HeaderContentType))
+	}
+	if format == "" {
+		return nil, "", errors.New("unable to detect the import file format, use the format query parameter")
+	}
+	return io.NopCloser(bytes.NewReader(c.Body())), format, nil
+}
+
+// buildFarmImportReport lists the outcome of every line of the import file, ordered by line
+func buildFarmImportReport(mode string, format string, records []*farmImportRecord) models.FarmImportReport {
+	report := models.FarmImportReport{
+		Mode:   mode,
+		Format: format,
+		Rows:   []models.FarmImportRowReport{},
+	}
+	for _, record := range records {
+		valid := record.isValid()
+		switch {
+		case !valid:
+			report.InvalidFarms++
+		case record.failed:
+			report.FailedFarms++
+		case record.farmID != nil:
+			report.ImportedFarms++
+		}
+		for _, line := range record.lines {
+			row := models.FarmImportRowReport{Line: line}
+			switch {
+			case !valid:
+				row.Status = models.FarmImportRowInvalid
+				row.Errors = append(append([]string{}, record.lineErrors[line]...), record.errors...)
+			case record.failed:
+				row.Status = models.FarmImportRowFailed
+				row.Errors = []string{"the farm could not be created"}
+			case record.farmID != nil:
+				row.Status = models.FarmImportRowImported
+				row.FarmID = record.farmID
+			default:
+				row.Status = models.FarmImportRowRejected
+			}
+			report.Rows = append(report.Rows, row)
+		}
+	}
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Line < report.Rows[j].Line
+	})
+	return report
+}
+
+// @Summary Import farms
+// @Description Creates farms in bulk from a CSV file, with one row per farm and crop production pair, or a NDJSON file, with one farm per line.
+// @Description The CSV rows are grouped by the optional farm_key column, or the id column of the exported files, or else consecutive rows with the same name, land area, unit measure and address belong to the same farm.
+// @Description The file is sent as the request body or in the file field of a multipart form.
+// @Description In the all_or_nothing mode the farms are created in a single transaction and nothing is imported when a row is invalid.
+// @Description In the skip_invalid mode the invalid farms are skipped and each valid farm is created on its own, the farms that could not be created are reported as failed.
+// @Tags Farm
+// @Accept text/csv,application/x-ndjson,mpfd
+// @Produce json
+// @Param mode query string false "Import mode" Enums(all_or_nothing, skip_invalid) default(all_or_nothing)
+// @Param format query string false "Import file format, detected from the file name or content type when omitted" Enums(csv, ndjson)
+// @Param file formData file false "Import file"
+// @Success 200 {object} models.FarmImportReport "Import Report"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 422 {object} models.FarmImportReport "Invalid Rows"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /farms/import [post]
+func (fc *FarmController) ImportFarms(c *fiber.Ctx) error {
+	mode := c.Query("mode", farmImportModeAllOrNothing)
+	if mode != farmImportModeAllOrNothing && mode != farmImportModeSkipInvalid {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: fmt.Sprintf("invalid mode %q, must be one of: %s, %s", mode, farmImportModeAllOrNothing, farmImportModeSkipInvalid),
+		})
+	}
+
+	file, format, err := farmImportFile(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{Error: err.Error()})
+	}
+	defer file.Close()
+
+	var records []*farmImportRecord
+	if format == farmImportFormatCSV {
+		records, err = parseFarmImportCSV(file)
+	} else {
+		records, err = parseFarmImportNDJSON(file)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{Error: err.Error()})
+	}
+	if len(records) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{Error: "the import file has no farms"})
+	}
+
+	var validRecords []*farmImportRecord
+	for _, record := range records {
+		if errs := record.farm.Validate(); len(errs) > 0 && errs[0].Error {
+			record.errors = validationErrorMessages(errs)
+		}
+		if record.isValid() {
+			validRecords = append(validRecords, record)
+		}
+	}
+
+	if mode == farmImportModeAllOrNothing && len(validRecords) < len(records) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(buildFarmImportReport(mode, format, records))
+	}
+
+	if len(validRecords) > 0 {
+		farms := make([]domain.Farm, 0, len(validRecords))
+		for _, record := range validRecords {
+			farms = append(farms, toDomainFarm(record.farm))
+		}
+		ctx := requestContext(c)
+		importedFarms, errs, err := fc.importFarmsUseCase.Execute(ctx, farms, mode == farmImportModeAllOrNothing)
+		if err != nil {
+			return fc.farmErrorResponse(c, err)
+		}
+		for i, record := range validRecords {
+			if i < len(errs) && errs[i] != nil {
+				fc.logger.Error(ctx, "Failed to import farm", errs[i], map[string]interface{}{"lines": record.lines})
+				record.failed = true
+				continue
+			}
+			if i < len(importedFarms) && importedFarms[i] != nil {
+				record.farmID = &importedFarms[i].ID
+			}
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(buildFarmImportReport(mode, format, records))
+}
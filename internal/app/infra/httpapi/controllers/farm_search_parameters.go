@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseFarmSearchFilters reads the farm search filters from the query string,
+// the returned error message is meant to be sent back to the client
+func parseFarmSearchFilters(c *fiber.Ctx) (*domain.FarmSearchParameters, error) {
+	queries := c.Queries()
+	searchParameters := &domain.FarmSearchParameters{
+		CropTypeMatch: domain.CropTypeMatchAny,
+	}
+	var err error
+
+	if name, exists := queries["name"]; exists {
+		searchParameters.Name = &name
+	}
+	if address, exists := queries["address"]; exists {
+		searchParameters.Address = &address
+	}
+	if rawUnitMeasure, exists := queries["unit_measure"]; exists {
+		unitMeasure, err := domain.ParseUnitMeasure(rawUnitMeasure)
+		if err != nil {
+			return nil, fmt.Errorf(`Query parameter "unit_measure" must be one of %s`, supportedUnitMeasures())
+		}
+		searchParameters.UnitMeasure = &unitMeasure
+	}
+	if searchParameters.LandAreaUnit, err = parseLandAreaUnit(c); err != nil {
+		return nil, err
+	}
+
+	// crop_type accepts repeated parameters as well as comma separated values
+	for _, value := range c.Context().QueryArgs().PeekMulti("crop_type") {
+		for _, rawCropType := range strings.Split(string(value), ",") {
+			cropType := domain.CropType(strings.ToUpper(strings.TrimSpace(rawCropType)))
+			if !cropType.IsValid() {
+				return nil, fmt.Errorf(`Query parameter "crop_type" has an invalid crop type "%s"`, rawCropType)
+			}
+			searchParameters.CropTypes = append(searchParameters.CropTypes, cropType)
+		}
+	}
+	if cropTypeMatch, exists := queries["crop_type_match"]; exists {
+		searchParameters.CropTypeMatch = domain.CropTypeMatch(cropTypeMatch)
+		if !searchParameters.CropTypeMatch.IsValid() {
+			return nil, fmt.Errorf(`Query parameter "crop_type_match" must be either "any" or "all"`)
+		}
+	}
+
+	if searchParameters.IsIrrigated, err = parseBoolQuery(queries, "is_irrigated"); err != nil {
+		return nil, err
+	}
+	if searchParameters.IsInsured, err = parseBoolQuery(queries, "is_insured"); err != nil {
+		return nil, err
+	}
+	if searchParameters.MinimumLandArea, err = parseFloatQuery(queries, "minimum_land_area"); err != nil {
+		return nil, err
+	}
+	if searchParameters.MaximumLandArea, err = parseFloatQuery(queries, "maximum_land_area"); err != nil {
+		return nil, err
+	}
+	if searchParameters.CreatedAfter, err = parseTimeQuery(queries, "created_after"); err != nil {
+		return nil, err
+	}
+	if searchParameters.CreatedBefore, err = parseTimeUpperBoundQuery(queries, "created_before"); err != nil {
+		return nil, err
+	}
+
+	includeDeleted, err := parseBoolQuery(queries, "include_deleted")
+	if err != nil {
+		return nil, err
+	}
+	searchParameters.IncludeDeleted = includeDeleted != nil && *includeDeleted
+	onlyDeleted, err := parseBoolQuery(queries, "only_deleted")
+	if err != nil {
+		return nil, err
+	}
+	searchParameters.OnlyDeleted = onlyDeleted != nil && *onlyDeleted
+
+	return searchParameters, nil
+}
+
+func parseBoolQuery(queries map[string]string, name string) (*bool, error) {
+	rawValue, exists := queries[name]
+	if !exists {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(rawValue)
+	if err != nil {
+		return nil, fmt.Errorf(`Query parameter "%s" must be a valid boolean`, name)
+	}
+	return &value, nil
+}
+
+func parseFloatQuery(queries map[string]string, name string) (*float64, error) {
+	rawValue, exists := queries[name]
+	if !exists {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		return nil, fmt.Errorf(`Query parameter "%s" must be a valid floating-point number`, name)
+	}
+	return &value, nil
+}
+
+// parseTimeQuery accepts either a RFC 3339 timestamp or a date in the YYYY-MM-DD format
+func parseTimeQuery(queries map[string]string, name string) (*time.Time, error) {
+	rawValue, exists := queries[name]
+	if !exists {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if value, err := time.Parse(layout, rawValue); err == nil {
+			return &value, nil
+		}
+	}
+	return nil, fmt.Errorf(`Query parameter "%s" must be a RFC 3339 timestamp or a YYYY-MM-DD date`, name)
+}
+
+// parseTimeUpperBoundQuery reads an exclusive upper bound, a YYYY-MM-DD date is read as the start of the next day
+// so the whole day is included
+func parseTimeUpperBoundQuery(queries map[string]string, name string) (*time.Time, error) {
+	if value, err := time.Parse(time.DateOnly, queries[name]); err == nil {
+		nextDay := value.AddDate(0, 0, 1)
+		return &nextDay, nil
+	}
+	return parseTimeQuery(queries, name)
+}
+
+// parseLandAreaUnit reads the unit used by the land area range and the returned land areas,
+// an empty unit is returned when the client doesn't request one
+func parseLandAreaUnit(c *fiber.Ctx) (domain.UnitMeasure, error) {
+	rawUnit := c.Query("unit")
+	if rawUnit == "" {
+		return "", nil
+	}
+	unit, err := domain.ParseUnitMeasure(rawUnit)
+	if err != nil {
+		return "", fmt.Errorf(`Query parameter "unit" must be one of %s`, supportedUnitMeasures())
+	}
+	return unit, nil
+}
+
+func supportedUnitMeasures() string {
+	units := make([]string, 0, len(domain.UnitMeasures()))
+	for _, unit := range domain.UnitMeasures() {
+		units = append(units, unit.String())
+	}
+	return strings.Join(units, ", ")
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	appName = "farm-api"
+	// databasePingTimeout bounds the readiness check so a hanging database doesn't hang the probes
+	databasePingTimeout = 2 * time.Second
+)
+
+type HealthController struct {
+	db           *sql.DB
+	shuttingDown atomic.Bool
+}
+
+// SetShuttingDown makes the readiness check fail so no new traffic is routed to the server while it stops
+func (hc *HealthController) SetShuttingDown() {
+	hc.shuttingDown.Store(true)
+}
+
+// @Summary Liveness check
+// @Description Reports that the process is up, it doesn't check the dependencies of the server
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport "Healthy"
+// @Router /healthcheck/live [get]
+func (hc *HealthController) Live(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(models.HealthReport{
+		Status:  models.HealthStatusHealthy,
+		AppName: appName,
+	})
+}
+
+// @Summary Readiness check
+// @Description Reports whether the server can handle requests: the database answers a ping and the server is not shutting down.
+// @Description The statistics of the database connection pool are included.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport "Ready"
+// @Failure 503 {object} models.HealthReport "Unavailable or shutting down"
+// @Router /healthcheck/ready [get]
+func (hc *HealthController) Ready(c *fiber.Ctx) error {
+	report := models.HealthReport{
+		Status:   models.HealthStatusReady,
+		AppName:  appName,
+		Database: hc.databaseHealth(c.Context()),
+	}
+	if hc.shuttingDown.Load() {
+		report.Status = models.HealthStatusShuttingDown
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	if !report.Database.Reachable {
+		report.Status = models.HealthStatusUnavailable
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+func (hc *HealthController) databaseHealth(ctx context.Context) *models.DatabaseHealth {
+	ctx, cancel := context.WithTimeout(ctx, databasePingTimeout)
+	defer cancel()
+	health := &models.DatabaseHealth{Reachable: true}
+	if err := hc.db.PingContext(ctx); err != nil {
+		health.Reachable = false
+		health.Error = err.Error()
+	}
+	stats := hc.db.Stats()
+	health.MaxOpenConnections = stats.MaxOpenConnections
+	health.OpenConnections = stats.OpenConnections
+	health.InUse = stats.InUse
+	health.Idle = stats.Idle
+	health.WaitCount = stats.WaitCount
+	health.WaitDuration = stats.WaitDuration.String()
+	health.MaxIdleClosed = stats.MaxIdleClosed
+	health.MaxLifetimeClosed = stats.MaxLifetimeClosed
+	return health
+}
+
+func NewHealthController(db *sql.DB) *HealthController {
+	return &HealthController{
+		db: db,
+	}
+}
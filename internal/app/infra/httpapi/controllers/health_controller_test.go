@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := []struct {
+		name               string
+		path               string
+		pingError          error
+		shuttingDown       bool
+		expectedStatusCode int
+		expectedStatus     string
+	}{
+		{
+			name:               "Live",
+			path:               "/healthcheck/live",
+			expectedStatusCode: fiber.StatusOK,
+			expectedStatus:     models.HealthStatusHealthy,
+		},
+		{
+			name:               "Live while shutting down",
+			path:               "/healthcheck/live",
+			shuttingDown:       true,
+			expectedStatusCode: fiber.StatusOK,
+			expectedStatus:     models.HealthStatusHealthy,
+		},
+		{
+			name:               "Ready",
+			path:               "/healthcheck/ready",
+			expectedStatusCode: fiber.StatusOK,
+			expectedStatus:     models.HealthStatusReady,
+		},
+		{
+			name:               "Database unreachable",
+			path:               "/healthcheck/ready",
+			pingError:          errors.New("connection refused"),
+			expectedStatusCode: fiber.StatusServiceUnavailable,
+			expectedStatus:     models.HealthStatusUnavailable,
+		},
+		{
+			name:               "Shutting down",
+			path:               "/healthcheck/ready",
+			shuttingDown:       true,
+			expectedStatusCode: fiber.StatusServiceUnavailable,
+			expectedStatus:     models.HealthStatusShuttingDown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			defer db.Close()
+			db.SetMaxOpenConns(7)
+			if tt.path == "/healthcheck/ready" {
+				mock.ExpectPing().WillReturnError(tt.pingError)
+			}
+
+			controller := NewHealthController(db)
+			if tt.shuttingDown {
+				controller.SetShuttingDown()
+			}
+			app := fiber.New()
+			app.Get("/healthcheck/live", controller.Live)
+			app.Get("/healthcheck/ready", controller.Ready)
+
+			req, err := http.NewRequest("GET", tt.path, nil)
+			require.NoError(t, err)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+			var report models.HealthReport
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+			assert.Equal(t, tt.expectedStatus, report.Status)
+			if tt.path == "/healthcheck/ready" {
+				require.NotNil(t, report.Database)
+				assert.Equal(t, tt.pingError == nil, report.Database.Reachable)
+				assert.Equal(t, 7, report.Database.MaxOpenConnections)
+			} else {
+				assert.Nil(t, report.Database)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain/usecases"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LogLevelController struct {
+	getLogLevelUseCase usecases.GetLogLevelUseCase
+	setLogLevelUseCase usecases.SetLogLevelUseCase
+	logger             *logger.Logger
+}
+
+// logLevelErrorResponse maps the errors returned by the log level use cases to HTTP responses
+func (lc *LogLevelController) logLevelErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, domain.ErrInvalidLogLevel) {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	var forbiddenError *shared.ForbiddenError
+	if errors.As(err, &forbiddenError) {
+		return c.Status(fiber.StatusForbidden).JSON(shared.CustomError{
+			Error: err.Error(),
+		})
+	}
+	lc.logger.Error(requestContext(c), "Unexpected error", err)
+	return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
+		Error: "Internal server error",
+	})
+}
+
+// @Summary Get the log level
+// @Description Get the minimum level of the logged entries
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} models.LogLevel "Log Level"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /admin/log-level [get]
+func (lc *LogLevelController) GetLogLevel(c *fiber.Ctx) error {
+	level, err := lc.getLogLevelUseCase.Execute(requestContext(c))
+	if err != nil {
+		return lc.logLevelErrorResponse(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(models.LogLevel{Level: level})
+}
+
+// @Summary Change the log level
+// @Description Change the minimum level of the logged entries until the server restarts, e.g. to debug a live issue
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param level body models.LogLevel true "Log Level, one of debug, info, warn or error"
+// @Success 200 {object} models.LogLevel "Log Level"
+// @Failure 400 {object} shared.CustomError "Bad Request"
+// @Failure 403 {object} shared.CustomError "Forbidden"
+// @Failure 500 {object} shared.CustomError "Internal Server Error"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /admin/log-level [put]
+func (lc *LogLevelController) SetLogLevel(c *fiber.Ctx) error {
+	var logLevel models.LogLevel
+	if err := c.BodyParser(&logLevel); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(shared.CustomError{
+			Error: "Invalid request body",
+		})
+	}
+	ctx := requestContext(c)
+	if err := lc.setLogLevelUseCase.Execute(ctx, logLevel.Level); err != nil {
+		return lc.logLevelErrorResponse(c, err)
+	}
+	lc.logger.Warn(ctx, "Log level changed", map[string]interface{}{"level": logLevel.Level})
+	return c.Status(fiber.StatusOK).JSON(logLevel)
+}
+
+func NewLogLevelController(
+	getLogLevelUseCase usecases.GetLogLevelUseCase,
+	setLogLevelUseCase usecases.SetLogLevelUseCase,
+	logger *logger.Logger,
+) *LogLevelController {
+	return &LogLevelController{
+		getLogLevelUseCase: getLogLevelUseCase,
+		setLogLevelUseCase: setLogLevelUseCase,
+		logger:             logger,
+	}
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/models"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockGetLogLevelUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGetLogLevelUseCase) Execute(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+type MockSetLogLevelUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSetLogLevelUseCase) Execute(ctx context.Context, level string) error {
+	return m.Called(ctx, level).Error(0)
+}
+
+type LogLevelControllerTestSuite struct {
+	suite.Suite
+	logger *logger.Logger
+}
+
+func (ls *LogLevelControllerTestSuite) SetupSuite() {
+	ls.logger = logger.NewLogger()
+}
+
+func (ls *LogLevelControllerTestSuite) TestGetLogLevel() {
+	tests := []struct {
+		name               string
+		mockLevel          string
+		mockError          error
+		expectedStatusCode int
+	}{
+		{
+			name:               "Successful log level retrieval",
+			mockLevel:          "info",
+			expectedStatusCode: fiber.StatusOK,
+		},
+		{
+			name:               "Missing permission",
+			mockError:          &shared.ForbiddenError{Permission: string(domain.PermissionManageLogs)},
+			expectedStatusCode: fiber.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			mockUseCase := new(MockGetLogLevelUseCase)
+			mockUseCase.On("Execute", mock.Anything).Return(tt.mockLevel, tt.mockError)
+			app := fiber.New()
+			app.Get("/admin/log-level", NewLogLevelController(mockUseCase, nil, ls.logger).GetLogLevel)
+
+			req, err := http.NewRequest("GET", "/admin/log-level", nil)
+			assert.NoError(ls.T(), err)
+			resp, err := app.Test(req)
+			assert.NoError(ls.T(), err)
+
+			assert.Equal(ls.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusOK {
+				var response models.LogLevel
+				assert.NoError(ls.T(), json.NewDecoder(resp.Body).Decode(&response))
+				assert.Equal(ls.T(), tt.mockLevel, response.Level)
+			}
+			mockUseCase.AssertExpectations(ls.T())
+		})
+	}
+}
+
+func (ls *LogLevelControllerTestSuite) TestSetLogLevel() {
+	tests := []struct {
+		name               string
+		body               string
+		mockRequired       bool
+		mockError          error
+		expectedStatusCode int
+	}{
+		{
+			name:               "Successful log level change",
+			body:               `{"level": "debug"}`,
+			mockRequired:       true,
+			expectedStatusCode: fiber.StatusOK,
+		},
+		{
+			name:               "Invalid level",
+			body:               `{"level": "verbose"}`,
+			mockRequired:       true,
+			mockError:          fmt.Errorf("%w, got %q", domain.ErrInvalidLogLevel, "verbose"),
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:               "Missing permission",
+			body:               `{"level": "debug"}`,
+			mockRequired:       true,
+			mockError:          &shared.ForbiddenError{Permission: string(domain.PermissionManageLogs)},
+			expectedStatusCode: fiber.StatusForbidden,
+		},
+		{
+			name:               "Invalid body",
+			body:               `{"level":`,
+			expectedStatusCode: fiber.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			mockUseCase := new(MockSetLogLevelUseCase)
+			if tt.mockRequired {
+				var level map[string]string
+				assert.NoError(ls.T(), json.Unmarshal([]byte(tt.body), &level))
+				mockUseCase.On("Execute", mock.Anything, level["level"]).Return(tt.mockError)
+			}
+			app := fiber.New()
+			app.Put("/admin/log-level", NewLogLevelController(nil, mockUseCase, ls.logger).SetLogLevel)
+
+			req, err := http.NewRequest("PUT", "/admin/log-level", strings.NewReader(tt.body))
+			assert.NoError(ls.T(), err)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(ls.T(), err)
+
+			assert.Equal(ls.T(), tt.expectedStatusCode, resp.StatusCode)
+			if tt.mockRequired {
+				mockUseCase.AssertExpectations(ls.T())
+			} else {
+				mockUseCase.AssertNotCalled(ls.T(), "Execute", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLogLevelControllerSuite(t *testing.T) {
+	suite.Run(t, new(LogLevelControllerTestSuite))
+}
@@ -4,4 +4,8 @@ import "go.uber.org/fx"
 
 var Module = fx.Provide(
 	NewFarmController,
+	NewCropProductionController,
+	NewAuditEventController,
+	NewHealthController,
+	NewLogLevelController,
 )
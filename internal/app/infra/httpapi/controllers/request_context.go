@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+// requestContext returns the context passed to the use cases and the logger, it carries the principal, the request ID
+// and the span set by the middlewares along with the route template of the request, which is only known by the handler
+func requestContext(c *fiber.Ctx) context.Context {
+	c.Locals(domain.RouteContextKey, c.Route().Path)
+	return middlewares.RequestContext(c)
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/middlewares"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDPropagation(t *testing.T) {
+	farmID := uuid.NewString()
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := logger.NewLoggerFromZap(zap.New(core))
+	mockUseCase := new(MockGetFarmUseCase)
+	mockUseCase.On("Execute", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.RequestIDFromContext(ctx) == "request-1" && domain.RouteFromContext(ctx) == "/farms/:id"
+	}), farmID).Return((*domain.Farm)(nil), errors.New("connection reset"))
+
+	app := fiber.New()
+	app.Use(middlewares.RequestID())
+	app.Use(middlewares.RequestLogger(log, config.Default().AccessLog))
+	controller := NewFarmController(nil, nil, nil, mockUseCase, nil, nil, nil, nil, nil, nil, nil, nil, log)
+	app.Get("/farms/:id", controller.GetFarm)
+
+	req, err := http.NewRequest("GET", "/farms/"+farmID, nil)
+	require.NoError(t, err)
+	req.Header.Set(fiber.HeaderXRequestID, "request-1")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "request-1", resp.Header.Get(fiber.HeaderXRequestID))
+	mockUseCase.AssertExpectations(t)
+	require.NotEmpty(t, logs.All())
+	for _, entry := range logs.All() {
+		assert.Equal(t, "request-1", entry.ContextMap()["requestid"], "entry %q", entry.Message)
+	}
+	errorLogs := logs.FilterMessage("Unexpected error").All()
+	require.Len(t, errorLogs, 1)
+	assert.Equal(t, "/farms/:id", errorLogs[0].ContextMap()["route"])
+	assert.Equal(t, "connection reset", errorLogs[0].ContextMap()["error"])
+	accessLogs := logs.FilterMessage("Request completed").All()
+	require.Len(t, accessLogs, 1)
+	assert.Equal(t, "/farms/:id", accessLogs[0].ContextMap()["route"])
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/auth"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// Authentication requires every request, except the ones to the public paths, to carry an API key in the X-API-Key header
+// or a JWT in the Authorization bearer header. The caller principal is stored in the request context under domain.PrincipalContextKey.
+// Public paths are matched exactly, or by prefix when they end with "*"
+func Authentication(authenticator *auth.Authenticator, log *logger.Logger, publicPaths ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if matchesPath(c.Path(), publicPaths) {
+			return c.Next()
+		}
+
+		var principal *domain.Principal
+		var err error
+		if apiKey := c.Get(APIKeyHeader); apiKey != "" {
+			principal, err = authenticator.AuthenticateAPIKey(RequestContext(c), apiKey)
+		} else {
+			principal, err = authenticator.AuthenticateBearerToken(bearerToken(c.Get(fiber.HeaderAuthorization)))
+		}
+
+		switch {
+		case errors.Is(err, auth.ErrMissingCredentials):
+			return unauthorized(c, "Missing credentials, send an API key in the X-API-Key header or a bearer token in the Authorization header")
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			log.Warn(RequestContext(c), "Authentication failed", map[string]interface{}{"reason": err.Error()})
+			return unauthorized(c, "Invalid credentials")
+		case err != nil:
+			log.Error(RequestContext(c), "Unexpected error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(shared.CustomError{
+				Error: "Internal server error",
+			})
+		}
+
+		c.Locals(domain.PrincipalContextKey, principal)
+		return c.Next()
+	}
+}
+
+func unauthorized(c *fiber.Ctx, message string) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer, ApiKey header="`+APIKeyHeader+`"`)
+	return c.Status(fiber.StatusUnauthorized).JSON(shared.CustomError{Error: message})
+}
+
+func bearerToken(authorization string) string {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// matchesPath reports whether path is one of the patterns, matched exactly or by prefix when they end with "*"
+func matchesPath(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
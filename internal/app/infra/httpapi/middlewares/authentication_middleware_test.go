@@ -0,0 +1,143 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/auth"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/errors"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAPIKeyRepository struct {
+	keys map[string]*domain.APIKey
+}
+
+func (r *stubAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	if key, ok := r.keys[keyHash]; ok {
+		return key, nil
+	}
+	return nil, &shared.NotFoundError{Resource: "API key", ID: keyHash}
+}
+
+func newAuthenticatedApp(t *testing.T) (*fiber.App, *domain.APIKey) {
+	apiKey := &domain.APIKey{ID: uuid.New(), Name: "finance", KeyHash: domain.HashAPIKey("secret-key")}
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = "test-secret"
+	authenticator, err := auth.NewAuthenticator(cfg, &stubAPIKeyRepository{
+		keys: map[string]*domain.APIKey{apiKey.KeyHash: apiKey},
+	})
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(Authentication(authenticator, logger.NewLogger(), "/healthcheck", "/swagger/*"))
+	app.Get("/healthcheck", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/swagger/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/farms", func(c *fiber.Ctx) error {
+		return c.JSON(domain.PrincipalFromContext(c.Context()))
+	})
+	return app, apiKey
+}
+
+func TestAuthentication(t *testing.T) {
+	app, apiKey := newAuthenticatedApp(t)
+	validToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		path               string
+		headers            map[string]string
+		expectedStatusCode int
+		expectedPrincipal  *domain.Principal
+	}{
+		{
+			name:               "Public healthcheck",
+			path:               "/healthcheck",
+			expectedStatusCode: fiber.StatusOK,
+		},
+		{
+			name:               "Public swagger",
+			path:               "/swagger/index.html",
+			expectedStatusCode: fiber.StatusOK,
+		},
+		{
+			name:               "Missing credentials",
+			path:               "/farms",
+			expectedStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:               "Valid API key",
+			path:               "/farms",
+			headers:            map[string]string{"X-API-Key": "secret-key"},
+			expectedStatusCode: fiber.StatusOK,
+			expectedPrincipal:  &domain.Principal{ID: apiKey.ID.String(), Name: "finance", Type: domain.PrincipalTypeAPIKey},
+		},
+		{
+			name:               "Unknown API key",
+			path:               "/farms",
+			headers:            map[string]string{"X-API-Key": "other-key"},
+			expectedStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:               "Valid bearer token",
+			path:               "/farms",
+			headers:            map[string]string{"Authorization": "Bearer " + validToken},
+			expectedStatusCode: fiber.StatusOK,
+			expectedPrincipal:  &domain.Principal{ID: "user-1", Type: domain.PrincipalTypeUser},
+		},
+		{
+			name:               "Invalid bearer token",
+			path:               "/farms",
+			headers:            map[string]string{"Authorization": "Bearer " + validToken + "x"},
+			expectedStatusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:               "Basic authorization scheme",
+			path:               "/farms",
+			headers:            map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			expectedStatusCode: fiber.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.path, nil)
+			require.NoError(t, err)
+			for header, value := range tt.headers {
+				req.Header.Set(header, value)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStatusCode, resp.StatusCode)
+			if tt.expectedStatusCode == fiber.StatusUnauthorized {
+				var body shared.CustomError
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.NotEmpty(t, body.Error)
+				assert.NotEmpty(t, resp.Header.Get("WWW-Authenticate"))
+			}
+			if tt.expectedPrincipal != nil {
+				var principal domain.Principal
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&principal))
+				assert.Equal(t, *tt.expectedPrincipal, principal)
+			}
+		})
+	}
+}
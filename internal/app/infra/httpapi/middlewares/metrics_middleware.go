@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Metrics counts every request and records its duration, labelled by method, route template and status.
+// The requests that don't reach a route handler, such as unknown paths, are labelled with the "/" route of the
+// middlewares
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		handleError(c, err)
+		// the method is copied since fiber reuses its buffer for the next requests, while the label values are kept
+		m.ObserveRequest(utils.CopyString(c.Method()), c.Route().Path, c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.NewMetrics()
+	app := fiber.New()
+	app.Use(Metrics(m))
+	app.Get("/metrics", adaptor.HTTPHandler(m.Handler()))
+	app.Get("/farms/:id", func(c *fiber.Ctx) error {
+		return c.SendString("farm")
+	})
+	app.Post("/farms/import", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusInternalServerError, "import failed")
+	})
+
+	for _, req := range []*http.Request{
+		httptestRequest(t, "GET", "/farms/farm-1"),
+		httptestRequest(t, "GET", "/farms/farm-2"),
+		httptestRequest(t, "POST", "/farms/import"),
+	} {
+		_, err := app.Test(req)
+		require.NoError(t, err)
+	}
+
+	resp, err := app.Test(httptestRequest(t, "GET", "/metrics"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `farm_api_http_requests_total{method="GET",route="/farms/:id",status="200"} 2`)
+	// the status written by the error handler is recorded
+	assert.Contains(t, string(body), `farm_api_http_requests_total{method="POST",route="/farms/import",status="500"} 1`)
+	assert.Contains(t, string(body), `farm_api_http_request_duration_seconds_count{method="GET",route="/farms/:id",status="200"} 2`)
+}
+
+func httptestRequest(t *testing.T, method string, path string) *http.Request {
+	req, err := http.NewRequest(method, path, nil)
+	require.NoError(t, err)
+	return req
+}
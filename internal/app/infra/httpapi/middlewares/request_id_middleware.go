@@ -0,0 +1,15 @@
+package middlewares
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// RequestID reads the request ID from the X-Request-ID header, or generates one, and sends it back in the response.
+// The ID is stored under domain.RequestIDContextKey, so domain.RequestIDFromContext finds it in c.Context().
+func RequestID() fiber.Handler {
+	return requestid.New(requestid.Config{
+		ContextKey: domain.RequestIDContextKey,
+	})
+}
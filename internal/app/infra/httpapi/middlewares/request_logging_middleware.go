@@ -1,40 +1,152 @@
 package middlewares
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
 	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"github.com/gofiber/fiber/v2"
 )
 
-func RequestLogger(log *logger.Logger) fiber.Handler {
+const redactedValue = "[REDACTED]"
+
+// RequestLogger writes a single access log line once each request completes, holding its status, latency, sizes and
+// route template. The 4xx responses also log the request body and every non 2xx response logs the response body, with
+// the configured JSON fields redacted and the bodies cut at the maximum size. The incoming requests are logged with
+// their redacted headers at the debug level. The requests to the skipped paths are not logged.
+func RequestLogger(log *logger.Logger, cfg config.AccessLogConfig) fiber.Handler {
+	redactedHeaders := lowercaseSet(cfg.RedactedHeaders)
+	redactedFields := lowercaseSet(cfg.RedactedFields)
 	return func(c *fiber.Ctx) error {
+		if matchesPath(c.Path(), cfg.SkipPaths) {
+			return c.Next()
+		}
 		start := time.Now()
+		ctx := RequestContext(c)
 
-		log.Info(c.Context(), "Incoming request", map[string]interface{}{
+		log.Debug(ctx, "Incoming request", map[string]interface{}{
 			"method":  c.Method(),
 			"path":    c.Path(),
 			"query":   c.Context().QueryArgs().String(),
-			"headers": c.GetReqHeaders(),
+			"headers": redactHeaders(c.GetReqHeaders(), redactedHeaders),
 		})
 
+		// the error handler sets the response status, which must be known before writing the access log
 		err := c.Next()
+		handleError(c, err)
 
-		duration := time.Since(start)
+		// the route is only known once the request went through the router, the logger adds it to the entry
+		c.Locals(domain.RouteContextKey, c.Route().Path)
 		statusCode := c.Response().StatusCode()
-		if statusCode < 200 || statusCode >= 300 {
-			log.Info(c.Context(), "Response sent", map[string]interface{}{
-				"status":   statusCode,
-				"duration": duration.String(),
-				"body":     string(c.Response().Body()),
-			})
-		} else {
-			log.Info(c.Context(), "Response sent", map[string]interface{}{
-				"status":   statusCode,
-				"duration": duration.String(),
-			})
+		fields := map[string]interface{}{
+			"method":    c.Method(),
+			"path":      c.Path(),
+			"status":    statusCode,
+			"latency":   time.Since(start).String(),
+			"bytes_in":  len(c.Request().Body()),
+			"bytes_out": responseSize(c),
+		}
+		if statusCode >= 400 && statusCode < 500 {
+			fields["request_body"] = loggedBody(c.Request().Body(), string(c.Request().Header.ContentType()), redactedFields, cfg.MaxBodySize)
+		}
+		if (statusCode < 200 || statusCode >= 300) && !c.Response().IsBodyStream() {
+			fields["response_body"] = loggedBody(c.Response().Body(), string(c.Response().Header.ContentType()), redactedFields, cfg.MaxBodySize)
 		}
+		switch {
+		case statusCode >= 500:
+			log.Error(ctx, "Request completed", err, fields)
+		case statusCode >= 400:
+			log.Warn(ctx, "Request completed", fields)
+		default:
+			log.Info(ctx, "Request completed", fields)
+		}
+		return nil
+	}
+}
+
+// handleError writes the response of an error returned by the next handlers with the error handler of the app, so the
+// middlewares can read the final status of the response, the error is not returned again
+func handleError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
+
+func lowercaseSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = true
+	}
+	return set
+}
 
-		return err
+func redactHeaders(headers map[string][]string, redacted map[string]bool) map[string][]string {
+	for name := range headers {
+		if redacted[strings.ToLower(name)] {
+			headers[name] = []string{redactedValue}
+		}
+	}
+	return headers
+}
+
+// responseSize returns the size of the response body, or its Content-Length when the body is streamed,
+// since reading a streamed body would consume it
+func responseSize(c *fiber.Ctx) int {
+	if c.Response().IsBodyStream() {
+		return c.Response().Header.ContentLength()
+	}
+	return len(c.Response().Body())
+}
+
+// loggedBody returns the body as it is logged: the redacted fields of JSON bodies are masked, and bodies longer than
+// maxSize bytes are cut, a maxSize of 0 disables the bodies logging. The other bodies can't be redacted, so they are
+// replaced by a placeholder holding their content type and size
+func loggedBody(body []byte, contentType string, redactedFields map[string]bool, maxSize int) string {
+	if len(body) == 0 || maxSize == 0 {
+		return ""
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if mediaType != fiber.MIMEApplicationJSON && !strings.HasSuffix(mediaType, "+json") {
+		if mediaType == "" {
+			mediaType = "untyped"
+		}
+		return fmt.Sprintf("<%s body, %d bytes>", mediaType, len(body))
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("<unparseable body, %d bytes>", len(body))
+	}
+	redactedBody, err := json.Marshal(redactJSON(value, redactedFields))
+	if err != nil {
+		return fmt.Sprintf("<unparseable body, %d bytes>", len(body))
+	}
+	if len(redactedBody) > maxSize {
+		return string(redactedBody[:maxSize]) + "...(truncated)"
+	}
+	return string(redactedBody)
+}
+
+func redactJSON(value interface{}, redactedFields map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			if redactedFields[strings.ToLower(key)] {
+				typed[key] = redactedValue
+			} else {
+				typed[key] = redactJSON(field, redactedFields)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactJSON(item, redactedFields)
+		}
 	}
+	return value
 }
@@ -0,0 +1,188 @@
+package middlewares
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newLoggedApp(cfg config.AccessLogConfig) (*fiber.App, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	app := fiber.New()
+	app.Use(RequestLogger(logger.NewLoggerFromZap(zap.New(core)), cfg))
+	app.Get("/healthcheck", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/farms/:id", func(c *fiber.Ctx) error {
+		return c.SendString("farm")
+	})
+	app.Post("/farms", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid farm", "token": "echoed-token"})
+	})
+	app.Post("/farms/import", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusInternalServerError, "import failed")
+	})
+	return app, logs
+}
+
+func sendRequest(t *testing.T, app *fiber.App, method string, path string, body string) {
+	sendRequestWithContentType(t, app, method, path, "application/json", body)
+}
+
+func sendRequestWithContentType(t *testing.T, app *fiber.App, method string, path string, contentType string, body string) {
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Correlation", "visible")
+	_, err = app.Test(req)
+	require.NoError(t, err)
+}
+
+func accessLog(t *testing.T, logs *observer.ObservedLogs) observer.LoggedEntry {
+	entries := logs.FilterMessage("Request completed").All()
+	require.Len(t, entries, 1)
+	return entries[0]
+}
+
+func TestRequestLoggerAccessLog(t *testing.T) {
+	app, logs := newLoggedApp(config.Default().AccessLog)
+
+	sendRequest(t, app, "GET", "/farms/farm-1", "")
+
+	entry := accessLog(t, logs)
+	fields := entry.ContextMap()
+	assert.Equal(t, zapcore.InfoLevel, entry.Level)
+	assert.Equal(t, "/farms/:id", fields["route"])
+	assert.Equal(t, "/farms/farm-1", fields["path"])
+	assert.Equal(t, int64(fiber.StatusOK), fields["status"])
+	assert.Equal(t, int64(len("farm")), fields["bytes_out"])
+	assert.NotEmpty(t, fields["latency"])
+	assert.NotContains(t, fields, "request_body")
+	assert.NotContains(t, fields, "response_body")
+
+	incoming := logs.FilterMessage("Incoming request").All()
+	require.Len(t, incoming, 1)
+	headers := incoming[0].ContextMap()["headers"].(map[string][]string)
+	assert.Equal(t, []string{redactedValue}, headers["Authorization"])
+	assert.Equal(t, []string{"visible"}, headers["X-Correlation"])
+}
+
+func TestRequestLoggerClientError(t *testing.T) {
+	app, logs := newLoggedApp(config.Default().AccessLog)
+
+	sendRequest(t, app, "POST", "/farms", `{"name": "Green Acres", "owner": {"password": "hunter2"}, "api_key": "abc"}`)
+
+	entry := accessLog(t, logs)
+	fields := entry.ContextMap()
+	assert.Equal(t, zapcore.WarnLevel, entry.Level)
+	assert.Equal(t, int64(fiber.StatusBadRequest), fields["status"])
+	assert.JSONEq(t, `{"name": "Green Acres", "owner": {"password": "[REDACTED]"}, "api_key": "[REDACTED]"}`, fields["request_body"].(string))
+	assert.JSONEq(t, `{"error": "invalid farm", "token": "[REDACTED]"}`, fields["response_body"].(string))
+}
+
+func TestRequestLoggerServerError(t *testing.T) {
+	app, logs := newLoggedApp(config.Default().AccessLog)
+
+	sendRequest(t, app, "POST", "/farms/import", `{"name": "Green Acres"}`)
+
+	entry := accessLog(t, logs)
+	fields := entry.ContextMap()
+	// the status set by the error handler is logged, the request body is only logged for client errors
+	assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+	assert.Equal(t, int64(fiber.StatusInternalServerError), fields["status"])
+	assert.Equal(t, "import failed", fields["error"])
+	assert.Equal(t, "<text/plain body, 13 bytes>", fields["response_body"])
+	assert.NotContains(t, fields, "request_body")
+}
+
+func TestRequestLoggerBodySize(t *testing.T) {
+	cfg := config.Default().AccessLog
+	cfg.MaxBodySize = 10
+	app, logs := newLoggedApp(cfg)
+
+	sendRequest(t, app, "POST", "/farms", `{"name": "Green Acres"}`)
+
+	assert.Equal(t, `{"name":"G...(truncated)`, accessLog(t, logs).ContextMap()["request_body"])
+}
+
+func TestRequestLoggerUnredactableBodies(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		expectedBody string
+	}{
+		{
+			name:         "Unparseable JSON",
+			contentType:  "application/json",
+			body:         `{"password": "hunter2"`,
+			expectedBody: "<unparseable body, 22 bytes>",
+		},
+		{
+			name:         "Form",
+			contentType:  "application/x-www-form-urlencoded",
+			body:         "password=hunter2",
+			expectedBody: "<application/x-www-form-urlencoded body, 16 bytes>",
+		},
+		{
+			name:         "Untyped",
+			contentType:  "",
+			body:         "password=hunter2",
+			expectedBody: "<untyped body, 16 bytes>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, logs := newLoggedApp(config.Default().AccessLog)
+
+			sendRequestWithContentType(t, app, "POST", "/farms", tt.contentType, tt.body)
+
+			assert.Equal(t, tt.expectedBody, accessLog(t, logs).ContextMap()["request_body"])
+		})
+	}
+}
+
+func TestRequestLoggerSkipPaths(t *testing.T) {
+	app, logs := newLoggedApp(config.Default().AccessLog)
+
+	sendRequest(t, app, "GET", "/healthcheck", "")
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestRequestLoggerKeepsEveryAccessLogEntry(t *testing.T) {
+	defaults := config.Default()
+	logOptions := defaults.Log.LoggerOptions()
+	logOptions.Output = logger.OutputFile
+	logOptions.File = filepath.Join(t.TempDir(), "access.log")
+	requestLogger, err := logger.NewLoggerFromOptions(logOptions)
+	require.NoError(t, err)
+	app := fiber.New()
+	app.Use(RequestLogger(requestLogger, defaults.AccessLog))
+	app.Get("/farms/:id", func(c *fiber.Ctx) error {
+		return c.SendString("farm")
+	})
+
+	// every entry has the same message, a sampled logger would drop most of them within the same second
+	const requests = 250
+	for i := 0; i < requests; i++ {
+		sendRequest(t, app, "GET", "/farms/farm-1", "")
+	}
+	requestLogger.Close()
+
+	content, err := os.ReadFile(logOptions.File)
+	require.NoError(t, err)
+	assert.Equal(t, requests, strings.Count(string(content), `"msg":"Request completed"`))
+}
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/tracing"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request, continuing the trace of the W3C traceparent header when the caller
+// sends one. The span is named after the route template once the request went through the router, and it is marked
+// as failed for 5xx responses.
+func Tracing(tracerProvider trace.TracerProvider) fiber.Handler {
+	tracer := tracerProvider.Tracer(tracing.InstrumentationName)
+	propagator := propagation.TraceContext{}
+	return func(c *fiber.Ctx) error {
+		// the strings of the fiber context are reused once the request completes, the span keeps copies of them
+		method := utils.CopyString(c.Method())
+		ctx, span := tracer.Start(propagator.Extract(c.UserContext(), requestHeaderCarrier{c}), method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(method), semconv.URLPath(utils.CopyString(c.Path()))),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		handleError(c, err)
+
+		statusCode := c.Response().StatusCode()
+		route := c.Route().Path
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(statusCode))
+		if statusCode >= 500 {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.SetStatus(codes.Error, strconv.Itoa(statusCode))
+		}
+		return nil
+	}
+}
+
+// requestHeaderCarrier reads the propagation headers, such as traceparent, from the request headers
+type requestHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h requestHeaderCarrier) Get(key string) string {
+	// the header value is copied since the extracted trace state keeps parts of it
+	return utils.CopyString(h.c.Get(key))
+}
+
+func (h requestHeaderCarrier) Set(key string, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h requestHeaderCarrier) Keys() []string {
+	keys := make([]string, 0)
+	h.c.Request().Header.VisitAll(func(key, value []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// RequestContext returns the context of the request for the handlers and the logger: the fasthttp context, which
+// holds the locals set by the middlewares such as the request ID, along with the span started by Tracing
+func RequestContext(c *fiber.Ctx) context.Context {
+	return trace.ContextWithSpan(c.Context(), trace.SpanFromContext(c.UserContext()))
+}
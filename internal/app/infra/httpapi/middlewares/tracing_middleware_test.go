@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTracedApp() (*fiber.App, *tracetest.SpanRecorder, *trace.SpanContext) {
+	recorder := tracetest.NewSpanRecorder()
+	app := fiber.New()
+	app.Use(Tracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	handlerSpan := new(trace.SpanContext)
+	app.Get("/farms/:id", func(c *fiber.Ctx) error {
+		*handlerSpan = trace.SpanContextFromContext(RequestContext(c))
+		return c.SendString("farm")
+	})
+	app.Post("/farms/import", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusInternalServerError, "import failed")
+	})
+	return app, recorder, handlerSpan
+}
+
+func TestTracingContinuesTraceparent(t *testing.T) {
+	app, recorder, handlerSpan := newTracedApp()
+
+	req := httptestRequest(t, "GET", "/farms/farm-1")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "GET /farms/:id", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.True(t, span.Parent().IsRemote())
+	// the handlers get the request span through RequestContext
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestTracingStartsTrace(t *testing.T) {
+	app, recorder, _ := newTracedApp()
+
+	_, err := app.Test(httptestRequest(t, "GET", "/farms/farm-1"))
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.True(t, spans[0].SpanContext().IsValid())
+	assert.False(t, spans[0].Parent().IsValid())
+}
+
+func TestTracingServerError(t *testing.T) {
+	app, recorder, _ := newTracedApp()
+
+	resp, err := app.Test(httptestRequest(t, "POST", "/farms/import"))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "POST /farms/import", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
@@ -0,0 +1,26 @@
+package routers
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type AdminRouter struct {
+	logLevelController *controllers.LogLevelController
+}
+
+func (ar *AdminRouter) Load(r *fiber.App) {
+	log.Info("Loading admin routes")
+	admin := r.Group("/admin")
+	admin.Get("/log-level", ar.logLevelController.GetLogLevel)
+	admin.Put("/log-level", ar.logLevelController.SetLogLevel)
+}
+
+func NewAdminRouter(
+	logLevelController *controllers.LogLevelController,
+) *AdminRouter {
+	return &AdminRouter{
+		logLevelController: logLevelController,
+	}
+}
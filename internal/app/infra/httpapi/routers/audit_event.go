@@ -0,0 +1,24 @@
+package routers
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type AuditEventRouter struct {
+	controller *controllers.AuditEventController
+}
+
+func (ar *AuditEventRouter) Load(r *fiber.App) {
+	log.Info("Loading audit event routes")
+	r.Get("/audit-events", ar.controller.ListAuditEvents)
+}
+
+func NewAuditEventRouter(
+	controller *controllers.AuditEventController,
+) *AuditEventRouter {
+	return &AuditEventRouter{
+		controller: controller,
+	}
+}
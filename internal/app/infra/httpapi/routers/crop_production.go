@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type CropProductionRouter struct {
+	controller *controllers.CropProductionController
+}
+
+func (cr *CropProductionRouter) Load(r *fiber.App) {
+	log.Info("Loading crop production routes")
+	r.Get("/farms/:id/crop-productions", cr.controller.ListCropProductions)
+	r.Post("/farms/:id/crop-productions", cr.controller.CreateCropProduction)
+	r.Patch("/farms/:id/crop-productions/:cropId", cr.controller.PatchCropProduction)
+	r.Delete("/farms/:id/crop-productions/:cropId", cr.controller.DeleteCropProduction)
+}
+
+func NewCropProductionRouter(
+	controller *controllers.CropProductionController,
+) *CropProductionRouter {
+	return &CropProductionRouter{
+		controller: controller,
+	}
+}
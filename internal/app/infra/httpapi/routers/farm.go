@@ -14,7 +14,15 @@ func (f *FarmRouter) Load(r *fiber.App) {
 	log.Info("Loading farm routes")
 	r.Post("/farms", f.controller.CreateFarm)
 	r.Get("/farms", f.controller.ListFarms)
+	r.Post("/farms/import", f.controller.ImportFarms)
+	r.Get("/farms/stats", f.controller.GetFarmStats)
+	r.Get("/farms/export", f.controller.ExportFarms)
+	r.Get("/farms/:id", f.controller.GetFarm)
+	r.Put("/farms/:id", f.controller.UpdateFarm)
+	r.Patch("/farms/:id", f.controller.PatchFarm)
 	r.Delete("/farms/:id", f.controller.DeleteFarm)
+	r.Post("/farms/:id/restore", f.controller.RestoreFarm)
+	r.Delete("/farms/:id/purge", f.controller.PurgeFarm)
 }
 
 func NewFarmRouter(
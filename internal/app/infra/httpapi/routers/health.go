@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type HealthRouter struct {
+	controller *controllers.HealthController
+}
+
+func (hr *HealthRouter) Load(r *fiber.App) {
+	log.Info("Loading health routes")
+	// /healthcheck is kept as the liveness check for the existing probes
+	r.Get("/healthcheck", hr.controller.Live)
+	r.Get("/healthcheck/live", hr.controller.Live)
+	r.Get("/healthcheck/ready", hr.controller.Ready)
+}
+
+func NewHealthRouter(
+	controller *controllers.HealthController,
+) *HealthRouter {
+	return &HealthRouter{
+		controller: controller,
+	}
+}
@@ -0,0 +1,25 @@
+package routers
+
+import (
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+type MetricsRouter struct {
+	metrics *metrics.Metrics
+}
+
+func (mr *MetricsRouter) Load(r *fiber.App) {
+	log.Info("Loading metrics routes")
+	r.Get("/metrics", adaptor.HTTPHandler(mr.metrics.Handler()))
+}
+
+func NewMetricsRouter(
+	metrics *metrics.Metrics,
+) *MetricsRouter {
+	return &MetricsRouter{
+		metrics: metrics,
+	}
+}
@@ -4,5 +4,10 @@ import "go.uber.org/fx"
 
 var Module = fx.Provide(
 	NewFarmRouter,
+	NewCropProductionRouter,
+	NewAuditEventRouter,
+	NewHealthRouter,
+	NewAdminRouter,
+	NewMetricsRouter,
 	MakeRouter,
 )
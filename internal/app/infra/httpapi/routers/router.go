@@ -2,12 +2,14 @@ package routers
 
 import (
 	_ "github.com/arthurgavazza/farm-api-challenge/docs"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/auth"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/middlewares"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/metrics"
 	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/swagger"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Router interface {
@@ -16,26 +18,38 @@ type Router interface {
 
 func MakeRouter(
 	farmRouter *FarmRouter,
+	cropProductionRouter *CropProductionRouter,
+	auditEventRouter *AuditEventRouter,
+	healthRouter *HealthRouter,
+	adminRouter *AdminRouter,
+	metrics *metrics.Metrics,
+	tracerProvider trace.TracerProvider,
 	config *config.Config,
+	authenticator *auth.Authenticator,
 	logger *logger.Logger,
 ) *fiber.App {
 	cfg := fiber.Config{
 		AppName:       "farm-api by @arthurgavazza",
 		CaseSensitive: true,
+		ReadTimeout:   config.Server.ReadTimeout,
+		WriteTimeout:  config.Server.WriteTimeout,
+		IdleTimeout:   config.Server.IdleTimeout,
 	}
 
 	r := fiber.New(cfg)
-	r.Use(requestid.New())
-	r.Use(middlewares.RequestLogger(logger))
+	r.Use(middlewares.RequestID())
+	r.Use(middlewares.Tracing(tracerProvider))
+	r.Use(middlewares.Metrics(metrics))
+	r.Use(middlewares.RequestLogger(logger, config.AccessLog))
+	r.Use(middlewares.Authentication(authenticator, logger, "/healthcheck", "/healthcheck/*", "/swagger/*"))
 	r.Get("/swagger/*", swagger.HandlerDefault)
-	r.Get("/healthcheck", func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"status":  "healthy",
-			"appName": "farm-api",
-		})
-	})
+
+	healthRouter.Load(r)
 
 	farmRouter.Load(r)
+	cropProductionRouter.Load(r)
+	auditEventRouter.Load(r)
+	adminRouter.Load(r)
 
 	return r
 }
@@ -3,8 +3,11 @@ package httpapi
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/controllers"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/httpapi/routers"
 	shared "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
 	"gorm.io/gorm"
 
@@ -18,13 +21,14 @@ func NewServer(
 	router *fiber.App,
 	config *config.Config,
 	logger *shared.Logger,
+	healthController *controllers.HealthController,
 	_ *gorm.DB,
 ) *fasthttp.Server {
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			go func() {
 				logger.Info(ctx, "Starting the server...")
-				addr := fmt.Sprintf(":%s", config.Server.Port)
+				addr := fmt.Sprintf(":%d", config.Server.Port)
 				if err := router.Listen(addr); err != nil {
 					logger.Fatal(ctx, "Error starting the server: %s\n", err)
 				}
@@ -32,11 +36,53 @@ func NewServer(
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			logger.Info(ctx, "Stopping the server...")
-			logger.Close()
+			logger.Info(ctx, "Stopping the server...", map[string]interface{}{"drain_delay": config.Server.ShutdownDrainDelay.String()})
+			healthController.SetShuttingDown()
+			// the server keeps serving while the load balancers notice the failing readiness check and stop routing to it
+			select {
+			case <-time.After(config.Server.ShutdownDrainDelay):
+			case <-ctx.Done():
+			}
 			return router.ShutdownWithContext(ctx)
 		},
 	})
 
 	return router.Server()
 }
+
+// MetricsServer serves the Prometheus metrics on the internal metrics port, apart from the public API
+type MetricsServer struct {
+	app *fiber.App
+}
+
+func NewMetricsServer(
+	lifecycle fx.Lifecycle,
+	metricsRouter *routers.MetricsRouter,
+	config *config.Config,
+	logger *shared.Logger,
+) *MetricsServer {
+	app := fiber.New(fiber.Config{
+		AppName:               "farm-api metrics",
+		DisableStartupMessage: true,
+		ReadTimeout:           config.Server.ReadTimeout,
+		WriteTimeout:          config.Server.WriteTimeout,
+		IdleTimeout:           config.Server.IdleTimeout,
+	})
+	metricsRouter.Load(app)
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				logger.Info(ctx, "Starting the metrics server...", map[string]interface{}{"port": config.Server.MetricsPort})
+				addr := fmt.Sprintf(":%d", config.Server.MetricsPort)
+				if err := app.Listen(addr); err != nil {
+					logger.Fatal(ctx, "Error starting the metrics server: %s\n", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return app.ShutdownWithContext(ctx)
+		},
+	})
+	return &MetricsServer{app: app}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "metrics:query_start"
+
+// GormPlugin records the duration of every query run through GORM, labelled by operation and table
+type GormPlugin struct {
+	metrics *Metrics
+}
+
+func NewGormPlugin(metrics *Metrics) *GormPlugin {
+	return &GormPlugin{metrics: metrics}
+}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		callbacks.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		callbacks.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		callbacks.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		callbacks.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		callbacks.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		callbacks.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		callbacks.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		callbacks.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		callbacks.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		callbacks.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		callbacks.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		start, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		p.metrics.ObserveQuery(operation, db.Statement.Table, time.Since(start.(time.Time)))
+	}
+}
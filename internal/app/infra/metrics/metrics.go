@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "farm_api"
+
+// Metrics holds the Prometheus collectors of the application. They are registered in their own registry,
+// along with the Go runtime and process collectors, so the tests can create several instances
+type Metrics struct {
+	registry               *prometheus.Registry
+	httpRequests           *prometheus.CounterVec
+	httpRequestDuration    *prometheus.HistogramVec
+	dbQueryDuration        *prometheus.HistogramVec
+	farmsCreated           prometheus.Counter
+	farmsDeleted           prometheus.Counter
+	cropProductionsCreated *prometheus.CounterVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of the HTTP requests by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of the database queries by operation and table.",
+			// from 1ms to about 2s, the queries are expected to be much faster than the HTTP requests
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"operation", "table"}),
+		farmsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "farms_created_total",
+			Help:      "Number of farms created, including the imported ones.",
+		}),
+		farmsDeleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "farms_deleted_total",
+			Help:      "Number of farms deleted.",
+		}),
+		cropProductionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "crop_productions_created_total",
+			Help:      "Number of crop productions created by crop type.",
+		}, []string{"crop_type"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.dbQueryDuration,
+		m.farmsCreated,
+		m.farmsDeleted,
+		m.cropProductionsCreated,
+	)
+	return m
+}
+
+// Handler serves the registered metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDBStats exposes the connection pool statistics of the database as gauges
+func (m *Metrics) RegisterDBStats(sqlDB *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(sqlDB, namespace))
+}
+
+// ObserveRequest counts a completed HTTP request, route is the template of the matched route to keep the number
+// of series bounded
+func (m *Metrics) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveQuery records the duration of a database query
+func (m *Metrics) ObserveQuery(operation string, table string, duration time.Duration) {
+	m.dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+func (m *Metrics) FarmCreated() {
+	m.farmsCreated.Inc()
+}
+
+func (m *Metrics) FarmDeleted() {
+	m.farmsDeleted.Inc()
+}
+
+func (m *Metrics) CropProductionCreated(cropType string) {
+	m.cropProductionsCreated.WithLabelValues(cropType).Inc()
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// scrape returns the metrics served by the handler in the Prometheus text format
+func scrape(t *testing.T, m *Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	body, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	m := NewMetrics()
+
+	m.FarmCreated()
+	m.FarmCreated()
+	m.FarmDeleted()
+	m.CropProductionCreated("RICE")
+	m.CropProductionCreated("CORN")
+	m.CropProductionCreated("RICE")
+	m.ObserveRequest("GET", "/farms/:id", 200, 30*time.Millisecond)
+	m.ObserveRequest("GET", "/farms/:id", 404, 10*time.Millisecond)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, "farm_api_farms_created_total 2")
+	assert.Contains(t, body, "farm_api_farms_deleted_total 1")
+	assert.Contains(t, body, `farm_api_crop_productions_created_total{crop_type="RICE"} 2`)
+	assert.Contains(t, body, `farm_api_crop_productions_created_total{crop_type="CORN"} 1`)
+	assert.Contains(t, body, `farm_api_http_requests_total{method="GET",route="/farms/:id",status="200"} 1`)
+	assert.Contains(t, body, `farm_api_http_requests_total{method="GET",route="/farms/:id",status="404"} 1`)
+	assert.Contains(t, body, `farm_api_http_request_duration_seconds_bucket{method="GET",route="/farms/:id",status="200",le="0.05"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestDatabaseMetrics(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 conn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	m := NewMetrics()
+	require.NoError(t, m.RegisterDBStats(conn))
+	require.NoError(t, db.Use(NewGormPlugin(m)))
+
+	mock.ExpectQuery(`SELECT \* FROM "farms"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("farm-1"))
+	var farms []map[string]interface{}
+	require.NoError(t, db.Table("farms").Find(&farms).Error)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `farm_api_db_query_duration_seconds_count{operation="query",table="farms"} 1`)
+	assert.Contains(t, body, `go_sql_max_open_connections{db_name="farm_api"}`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
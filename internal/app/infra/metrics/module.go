@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// registerDatabaseMetrics instruments the queries and the connection pool of the database
+func registerDatabaseMetrics(db *gorm.DB, sqlDB *sql.DB, metrics *Metrics) error {
+	if err := metrics.RegisterDBStats(sqlDB); err != nil {
+		return err
+	}
+	return db.Use(NewGormPlugin(metrics))
+}
+
+var Module = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			NewMetrics,
+			fx.As(fx.Self()),
+			fx.As(new(domain.MetricsRecorder)),
+		),
+	),
+	fx.Invoke(registerDatabaseMetrics),
+)
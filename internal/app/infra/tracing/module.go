@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	logger "github.com/arthurgavazza/farm-api-challenge/internal/app/shared/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// newConfiguredTracerProvider sets the tracer provider as the global one, used by the use cases spans, and flushes the
+// pending spans when the application stops
+func newConfiguredTracerProvider(lifecycle fx.Lifecycle, config *config.Config, logger *logger.Logger) (*sdktrace.TracerProvider, error) {
+	tracerProvider, err := NewTracerProvider(config.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn(context.Background(), "Tracing error, such as spans that could not be exported", map[string]interface{}{"error": err.Error()})
+	}))
+	lifecycle.Append(fx.Hook{
+		OnStop: tracerProvider.Shutdown,
+	})
+	return tracerProvider, nil
+}
+
+// registerDatabaseTracing traces the queries of the database
+func registerDatabaseTracing(db *gorm.DB, tracerProvider trace.TracerProvider) error {
+	return db.Use(NewGormPlugin(tracerProvider))
+}
+
+var Module = fx.Options(
+	fx.Provide(
+		fx.Annotate(
+			newConfiguredTracerProvider,
+			fx.As(new(trace.TracerProvider)),
+		),
+	),
+	fx.Invoke(registerDatabaseTracing),
+)
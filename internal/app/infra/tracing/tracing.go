@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// InstrumentationName names the tracers of the application spans
+const InstrumentationName = "github.com/arthurgavazza/farm-api-challenge"
+
+// NewTracerProvider returns a tracer provider exporting the spans with the configured exporter. The spans are sampled
+// as their parent, so the sampling decision of the caller sending a traceparent header is honored, and the spans
+// of requests without a parent are all sampled.
+func NewTracerProvider(cfg config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+	}
+	switch cfg.Exporter {
+	case config.TracingExporterOTLP:
+		// the exporter connects lazily, an unreachable collector doesn't prevent the application from starting
+		exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case config.TracingExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case config.TracingExporterNone:
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	return sdktrace.NewTracerProvider(options...), nil
+}
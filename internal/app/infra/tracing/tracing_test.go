@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/infra/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newTracedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 conn,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	require.NoError(t, db.Use(NewGormPlugin(tracerProvider)))
+	return db, mock, tracerProvider, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestGormPluginQuerySpan(t *testing.T) {
+	db, mock, tracerProvider, recorder := newTracedDatabase(t)
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "GET /farms")
+
+	mock.ExpectQuery(`SELECT \* FROM "farms"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("farm-1"))
+	var farms []map[string]interface{}
+	require.NoError(t, db.WithContext(ctx).Table("farms").Find(&farms).Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "gorm.query", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext().TraceID())
+	attributes := spanAttributes(span)
+	assert.Equal(t, "postgresql", attributes["db.system"].AsString())
+	assert.Equal(t, "farms", attributes["db.collection.name"].AsString())
+	assert.Equal(t, `SELECT * FROM "farms"`, attributes["db.query.text"].AsString())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGormPluginFailedQuerySpan(t *testing.T) {
+	db, mock, _, recorder := newTracedDatabase(t)
+
+	mock.ExpectExec(`DELETE FROM "farms"`).WillReturnError(errors.New("connection reset"))
+	require.Error(t, db.Exec(`DELETE FROM "farms" WHERE id = ?`, "farm-1").Error)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "gorm.raw", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection reset", spans[0].Status().Description)
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	for _, exporter := range []string{config.TracingExporterOTLP, config.TracingExporterStdout, config.TracingExporterNone} {
+		cfg := config.Default().Tracing
+		cfg.Exporter = exporter
+		cfg.OTLPEndpoint = "http://localhost:4318"
+		tracerProvider, err := NewTracerProvider(cfg)
+		require.NoError(t, err, exporter)
+		assert.NoError(t, tracerProvider.Shutdown(context.Background()))
+	}
+
+	cfg := config.Default().Tracing
+	cfg.Exporter = "jaeger"
+	_, err := NewTracerProvider(cfg)
+	assert.EqualError(t, err, `unknown tracing exporter "jaeger"`)
+}
@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	FarmImportRowImported = "imported"
+	FarmImportRowInvalid  = "invalid"
+	// FarmImportRowRejected marks valid rows that were not imported because another row is invalid
+	FarmImportRowRejected = "rejected"
+	// FarmImportRowFailed marks valid rows whose farm could not be created in the skip_invalid mode
+	FarmImportRowFailed = "failed"
+)
+
+// FarmImportRowReport describes the outcome of one line of an import file
+type FarmImportRowReport struct {
+	Line   int        `json:"line"`
+	Status string     `json:"status"`
+	FarmID *uuid.UUID `json:"farm_id,omitempty"`
+	Errors []string   `json:"errors,omitempty"`
+}
+
+// FarmImportReport is returned by the farm import, with one entry per line of the import file
+type FarmImportReport struct {
+	Mode          string                `json:"mode"`
+	Format        string                `json:"format"`
+	ImportedFarms int                   `json:"imported_farms"`
+	InvalidFarms  int                   `json:"invalid_farms"`
+	FailedFarms   int                   `json:"failed_farms"`
+	Rows          []FarmImportRowReport `json:"rows"`
+}
@@ -0,0 +1,30 @@
+package models
+
+const (
+	HealthStatusHealthy      = "healthy"
+	HealthStatusReady        = "ready"
+	HealthStatusUnavailable  = "unavailable"
+	HealthStatusShuttingDown = "shutting_down"
+)
+
+// DatabaseHealth reports the result of the database ping along with the statistics of the connection pool
+type DatabaseHealth struct {
+	Reachable          bool   `json:"reachable"`
+	Error              string `json:"error,omitempty"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	// WaitCount and WaitDuration are the number of connections waited for and the total time spent waiting
+	WaitCount         int64  `json:"wait_count"`
+	WaitDuration      string `json:"wait_duration"`
+	MaxIdleClosed     int64  `json:"max_idle_closed"`
+	MaxLifetimeClosed int64  `json:"max_lifetime_closed"`
+}
+
+// HealthReport is returned by the liveness and readiness checks, Database is only set by the readiness check
+type HealthReport struct {
+	Status   string          `json:"status"`
+	AppName  string          `json:"appName"`
+	Database *DatabaseHealth `json:"database,omitempty"`
+}
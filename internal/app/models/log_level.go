@@ -0,0 +1,6 @@
+package models
+
+// LogLevel is the minimum level of the logged entries, one of debug, info, warn or error
+type LogLevel struct {
+	Level string `json:"level" example:"debug"`
+}
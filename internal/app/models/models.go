@@ -6,3 +6,10 @@ type PaginatedResponse[T any] struct {
 	CurrentPage int   `json:"current_page"`
 	PerPage     int   `json:"per_page"`
 }
+
+// CursorPaginatedResponse is returned by the keyset paginated listings, NextCursor is nil on the last page
+type CursorPaginatedResponse[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	Limit      int     `json:"limit"`
+}
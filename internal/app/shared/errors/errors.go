@@ -14,3 +14,12 @@ func (e *NotFoundError) Error() string {
 type CustomError struct {
 	Error string `json:"error"`
 }
+
+// ForbiddenError is returned when the caller lacks the permission required by an operation
+type ForbiddenError struct {
+	Permission string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("permission %s is required", e.Permission)
+}
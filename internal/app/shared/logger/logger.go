@@ -2,79 +2,195 @@ package shared
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	// OutputFile writes the logs to Options.File, rotated once it reaches FileMaxSizeMB
+	OutputFile = "file"
+)
+
+// Options.Level is one of debug, info, warn or error. The sampling keeps the first SamplingInitial entries with the same
+// level and message every second, then one in SamplingThereafter, a SamplingInitial of 0 disables the sampling.
+type Options struct {
+	Level              string
+	Format             string
+	Output             string
+	File               string
+	FileMaxSizeMB      int
+	FileMaxBackups     int
+	FileMaxAgeDays     int
+	FileCompress       bool
+	SamplingInitial    int
+	SamplingThereafter int
+}
+
+// DefaultOptions logs the info entries and above as JSON to the standard error, without sampling
+func DefaultOptions() Options {
+	return Options{Level: "info", Format: FormatJSON, Output: OutputStderr}
+}
+
 type Logger struct {
 	zapLogger *zap.Logger
+	// level is shared by the loggers returned by With, so changing it applies to all of them
+	level zap.AtomicLevel
 }
 
 func NewLogger() *Logger {
-	logger, err := zap.NewProduction()
+	logger, err := NewLoggerFromOptions(DefaultOptions())
 	if err != nil {
 		panic(err)
 	}
-	return &Logger{zapLogger: logger}
+	return logger
 }
-func (l *Logger) Error(ctx context.Context, msg string, err error, context ...map[string]interface{}) {
-	var zapFields []zap.Field
-	if len(context) > 0 && context[0] != nil {
-		for key, value := range context[0] {
-			zapFields = append(zapFields, zap.Any(key, value))
-		}
-	}
-	zapFields = append(zapFields, zap.Error(err))
-	l.zapLogger.Error(msg, zapFields...)
+
+// NewLoggerFromZap wraps an existing zap logger, such as an observed logger in the tests.
+// The level of the wrapped logger is not managed by SetLevel.
+func NewLoggerFromZap(zapLogger *zap.Logger) *Logger {
+	return &Logger{zapLogger: zapLogger, level: zap.NewAtomicLevel()}
 }
 
-func (l *Logger) Info(ctx context.Context, msg string, context ...map[string]interface{}) {
-	var zapFields []zap.Field
+// NewLoggerFromOptions returns a logger with the level, encoding, output and sampling of the options
+func NewLoggerFromOptions(options Options) (*Logger, error) {
+	level, err := parseLevel(options.Level)
+	if err != nil {
+		return nil, err
+	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
 
-	if len(context) > 0 && context[0] != nil {
-		for key, value := range context[0] {
-			zapFields = append(zapFields, zap.Any(key, value))
-		}
+	encoderConfig := zap.NewProductionEncoderConfig()
+	var encoder zapcore.Encoder
+	switch options.Format {
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case FormatConsole:
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", options.Format)
 	}
-	requestId, ok := ctx.Value("requestid").(string)
-	if !ok {
-		requestId = "unknown"
+
+	var output zapcore.WriteSyncer
+	switch options.Output {
+	case OutputStdout:
+		output = zapcore.Lock(os.Stdout)
+	case OutputStderr:
+		output = zapcore.Lock(os.Stderr)
+	case OutputFile:
+		output = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   options.File,
+			MaxSize:    options.FileMaxSizeMB,
+			MaxBackups: options.FileMaxBackups,
+			MaxAge:     options.FileMaxAgeDays,
+			Compress:   options.FileCompress,
+		})
+	default:
+		return nil, fmt.Errorf("unknown log output %q", options.Output)
 	}
-	l.zapLogger.With(zap.String("requestid", requestId)).Info(msg, zapFields...)
+
+	core := zapcore.NewCore(encoder, output, atomicLevel)
+	if options.SamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, options.SamplingInitial, options.SamplingThereafter)
+	}
+	// the caller is skipped once so the entries point to the caller of the Logger methods instead of this file
+	zapLogger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.AddStacktrace(zapcore.ErrorLevel))
+	return &Logger{zapLogger: zapLogger, level: atomicLevel}, nil
 }
 
-func (l *Logger) Warn(ctx context.Context, msg string, context ...map[string]interface{}) {
-	var zapFields []zap.Field
-	if len(context) > 0 && context[0] != nil {
-		for key, value := range context[0] {
-			zapFields = append(zapFields, zap.Any(key, value))
-		}
+// parseLevel accepts the levels that can be configured, the zap panic and fatal levels would silence the errors
+func parseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug", "info", "warn", "error":
+		return zapcore.ParseLevel(level)
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("%w, got %q", domain.ErrInvalidLogLevel, level)
 	}
-	requestId, ok := ctx.Value("requestid").(string)
-	if !ok {
-		requestId = "unknown"
+}
+
+// Level returns the current minimum level of the logged entries
+func (l *Logger) Level() string {
+	return l.level.Level().String()
+}
+
+// SetLevel changes the minimum level of the logged entries, it returns domain.ErrInvalidLogLevel for unknown levels
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
 	}
-	l.zapLogger.With(zap.String("requestid", requestId)).Warn(msg, zapFields...)
+	l.level.SetLevel(parsed)
+	return nil
 }
 
-func (l *Logger) Fatal(ctx context.Context, msg string, err error, context ...map[string]interface{}) {
+// With returns a logger adding the fields to every entry, such as the ID of the farm being handled
+func (l *Logger) With(fields map[string]interface{}) *Logger {
+	return &Logger{zapLogger: l.zapLogger.With(toZapFields(fields)...), level: l.level}
+}
+
+func toZapFields(fields map[string]interface{}) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for key, value := range fields {
+		zapFields = append(zapFields, zap.Any(key, value))
+	}
+	return zapFields
+}
+
+// requestFields returns the zap fields of an entry: the given context fields along with the request ID,
+// the route template and the trace and span IDs of ctx
+func requestFields(ctx context.Context, context []map[string]interface{}) []zap.Field {
 	var zapFields []zap.Field
 	if len(context) > 0 && context[0] != nil {
-		for key, value := range context[0] {
-			zapFields = append(zapFields, zap.Any(key, value))
-		}
+		zapFields = toZapFields(context[0])
 	}
-	requestId, ok := ctx.Value("requestid").(string)
-	if !ok {
+	requestId := domain.RequestIDFromContext(ctx)
+	if requestId == "" {
 		requestId = "unknown"
 	}
-	// Add the error as a field
-	zapFields = append(zapFields, zap.Error(err))
-	l.zapLogger.With(zap.String("requestid", requestId)).Fatal(msg, zapFields...)
+	zapFields = append(zapFields, zap.String("requestid", requestId))
+	if route := domain.RouteFromContext(ctx); route != "" {
+		zapFields = append(zapFields, zap.String("route", route))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		zapFields = append(zapFields, zap.String("trace_id", spanContext.TraceID().String()), zap.String("span_id", spanContext.SpanID().String()))
+	}
+	return zapFields
+}
 
-	// Terminate the application
-	os.Exit(1)
+func (l *Logger) Debug(ctx context.Context, msg string, context ...map[string]interface{}) {
+	l.zapLogger.Debug(msg, requestFields(ctx, context)...)
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, err error, context ...map[string]interface{}) {
+	zapFields := append(requestFields(ctx, context), zap.Error(err))
+	l.zapLogger.Error(msg, zapFields...)
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, context ...map[string]interface{}) {
+	l.zapLogger.Info(msg, requestFields(ctx, context)...)
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, context ...map[string]interface{}) {
+	l.zapLogger.Warn(msg, requestFields(ctx, context)...)
+}
+
+// Fatal logs the error and terminates the application, zap exits with status 1 once the entry is written
+func (l *Logger) Fatal(ctx context.Context, msg string, err error, context ...map[string]interface{}) {
+	zapFields := append(requestFields(ctx, context), zap.Error(err))
+	l.zapLogger.Fatal(msg, zapFields...)
 }
 func (l *Logger) Close() {
 	_ = l.zapLogger.Sync()
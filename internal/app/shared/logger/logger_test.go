@@ -0,0 +1,133 @@
+package shared
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func fileLogOptions(t *testing.T, format string) Options {
+	options := DefaultOptions()
+	options.Format = format
+	options.Output = OutputFile
+	options.File = filepath.Join(t.TempDir(), "farm-api.log")
+	return options
+}
+
+func readEntries(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLoggerLevelAndFields(t *testing.T) {
+	options := fileLogOptions(t, FormatJSON)
+	logger, err := NewLoggerFromOptions(options)
+	require.NoError(t, err)
+	ctx := domain.ContextWithRequestID(context.Background(), "request-1")
+	farmLogger := logger.With(map[string]interface{}{"farmId": "farm-1"})
+
+	farmLogger.Debug(ctx, "Skipped at the info level")
+	farmLogger.Info(ctx, "Updating farm")
+	require.NoError(t, logger.SetLevel("debug"))
+	assert.Equal(t, "debug", farmLogger.Level())
+	farmLogger.Debug(ctx, "Logged at the debug level", map[string]interface{}{"step": "query"})
+	logger.Close()
+
+	entries := readEntries(t, options.File)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Updating farm", entries[0]["msg"])
+	assert.Equal(t, "info", entries[0]["level"])
+	assert.Equal(t, "farm-1", entries[0]["farmId"])
+	assert.Equal(t, "request-1", entries[0]["requestid"])
+	assert.Contains(t, entries[0]["caller"], "logger_test.go")
+	assert.Equal(t, "Logged at the debug level", entries[1]["msg"])
+	assert.Equal(t, "farm-1", entries[1]["farmId"])
+	assert.Equal(t, "query", entries[1]["step"])
+}
+
+func TestLoggerTraceFields(t *testing.T) {
+	options := fileLogOptions(t, FormatJSON)
+	logger, err := NewLoggerFromOptions(options)
+	require.NoError(t, err)
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+
+	logger.Info(trace.ContextWithSpanContext(context.Background(), spanContext), "Traced entry")
+	logger.Info(context.Background(), "Untraced entry")
+	logger.Close()
+
+	entries := readEntries(t, options.File)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entries[0]["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", entries[0]["span_id"])
+	assert.NotContains(t, entries[1], "trace_id")
+}
+
+func TestLoggerSetInvalidLevel(t *testing.T) {
+	logger, err := NewLoggerFromOptions(fileLogOptions(t, FormatJSON))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, logger.SetLevel("fatal"), domain.ErrInvalidLogLevel)
+	assert.Equal(t, "info", logger.Level())
+}
+
+func TestLoggerSampling(t *testing.T) {
+	options := fileLogOptions(t, FormatJSON)
+	options.SamplingInitial = 2
+	options.SamplingThereafter = 0
+	logger, err := NewLoggerFromOptions(options)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		logger.Info(context.Background(), "Repeated entry")
+	}
+	logger.Close()
+
+	assert.Len(t, readEntries(t, options.File), 2)
+}
+
+func TestLoggerConsoleFormat(t *testing.T) {
+	options := fileLogOptions(t, FormatConsole)
+	logger, err := NewLoggerFromOptions(options)
+	require.NoError(t, err)
+
+	logger.Warn(context.Background(), "Console entry")
+	logger.Close()
+
+	content, err := os.ReadFile(options.File)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "WARN"))
+	assert.True(t, strings.Contains(string(content), "Console entry"))
+}
+
+func TestNewLoggerFromInvalidOptions(t *testing.T) {
+	options := DefaultOptions()
+	options.Level = "verbose"
+	_, err := NewLoggerFromOptions(options)
+	assert.ErrorIs(t, err, domain.ErrInvalidLogLevel)
+
+	options = DefaultOptions()
+	options.Format = "xml"
+	_, err = NewLoggerFromOptions(options)
+	assert.EqualError(t, err, `unknown log format "xml"`)
+}
@@ -1,9 +1,33 @@
 package shared
 
 import (
+	"context"
+
+	"github.com/arthurgavazza/farm-api-challenge/internal/app/domain"
 	"go.uber.org/fx"
 )
 
+// newConfiguredLogger returns the logger of the options provided by the configuration
+func newConfiguredLogger(lifecycle fx.Lifecycle, options Options) (*Logger, error) {
+	logger, err := NewLoggerFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	// the hook is appended before the hooks of the components using the logger, fx runs the stop hooks in reverse
+	// order so the logger is flushed once the servers, the tracer provider and the database are stopped
+	lifecycle.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			logger.Close()
+			return nil
+		},
+	})
+	return logger, nil
+}
+
 var Module = fx.Provide(
-	NewLogger,
+	fx.Annotate(
+		newConfiguredLogger,
+		fx.As(fx.Self()),
+		fx.As(new(domain.LogLevelManager)),
+	),
 )
@@ -34,3 +34,10 @@ func ValidateStruct(data interface{}) []ErrorResponse {
 	errs := validate.Struct(data)
 	return parseValidationError(errs)
 }
+
+// RegisterValidation adds a custom validation tag to the shared validator
+func RegisterValidation(tag string, fn validator.Func) {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		panic(err)
+	}
+}